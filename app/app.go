@@ -9,10 +9,13 @@ import (
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/katakeda/boardhop-api-service-go/repositories"
 	"github.com/katakeda/boardhop-api-service-go/services"
+	"github.com/katakeda/boardhop-api-service-go/verifier"
 )
 
 type App struct {
-	router *gin.Engine
+	router   *gin.Engine
+	repo     repositories.IRepository
+	verifier verifier.Verifier
 }
 
 func (app *App) Initialize() {
@@ -26,27 +29,91 @@ func (app *App) Initialize() {
 		log.Fatalln("Failed to initialize repository", err)
 	}
 
+	app.repo = repo
+
 	svc, err := services.NewService(repo)
 	if err != nil {
 		log.Fatalln("Failed to initialize service", err)
 	}
 
-	app.router = gin.Default()
-	app.router.GET("/posts", svc.GetPosts)
-	app.router.GET("/posts/:id", svc.GetPost)
+	app.verifier, err = verifier.NewVerifier(context.Background())
+	if err != nil {
+		log.Fatalln("Failed to initialize token verifier", err)
+	}
+
+	go svc.ListenMessageEvents(context.Background())
+	go svc.RunPickupReminders(context.Background())
+	go svc.RunSavedSearchDigests(context.Background())
+
+	app.router = gin.New()
+	app.router.Use(gin.LoggerWithFormatter(redactedLogFormatter), gin.Recovery())
+	app.router.GET("/posts", app.AuthOptional(), svc.GetPosts)
+	app.router.GET("/posts/:id", app.AuthOptional(), svc.GetPost)
 	app.router.GET("/tags", svc.GetTags)
+	app.router.GET("/tag-types", svc.GetTagTypes)
 	app.router.GET("/categories", svc.GetCategories)
-	app.router.GET("/user", AuthRequired(), svc.GetUser)
-	app.router.GET("/orders", AuthRequired(), svc.GetOrders)
-	app.router.GET("/orders/:id", AuthRequired(), svc.GetOrder)
+	app.router.GET("/categories/:id/breadcrumb", svc.GetCategoryBreadcrumb)
+	app.router.GET("/categories/:id/attributes", svc.GetCategoryAttributes)
+	app.router.GET("/user", app.AuthRequired(), svc.GetUser)
+	app.router.GET("/user/favorites", app.AuthRequired(), svc.GetFavorites)
+	app.router.GET("/user/saved-searches", app.AuthRequired(), svc.GetSavedSearches)
+	app.router.GET("/user/export", app.AuthRequired(), svc.ExportUser)
+	app.router.GET("/user/notifications", app.AuthRequired(), svc.GetNotificationPreference)
+	app.router.GET("/users/:id", svc.GetPublicUser)
+	app.router.GET("/orders", app.AuthRequired(), svc.GetOrders)
+	app.router.GET("/orders/:id", app.AuthRequired(), svc.GetOrder)
+	app.router.GET("/orders/:id/messages", app.AuthRequired(), svc.GetOrderMessages)
+	app.router.GET("/posts/:id/reviews", svc.GetPostReviews)
+	app.router.GET("/orders/:id/reviews", app.AuthRequired(), svc.GetOrderReviews)
+	app.router.GET("/posts/:id/messages", app.AuthRequired(), svc.GetPostMessages)
+	app.router.GET("/messages/stream", app.StreamAuthRequired(), svc.StreamMessages)
+	app.router.GET("/messages/unread", app.AuthRequired(), svc.GetUnreadCounts)
+	app.router.GET("/notifications", app.AuthRequired(), svc.GetNotifications)
+	app.router.GET("/notifications/unread", app.AuthRequired(), svc.GetUnreadNotificationCount)
+
+	app.router.POST("/user/signup", app.AuthRequired(), svc.UserSignup)
+	app.router.POST("/user/login", app.AuthRequired(), svc.UserLogin)
+	app.router.POST("/posts", app.AuthRequired(), app.ActiveUserRequired(), svc.CreatePost)
+	app.router.POST("/orders", app.AuthRequired(), app.ActiveUserRequired(), svc.CreateOrder)
+	app.router.POST("/messages", app.AuthRequired(), app.ActiveUserRequired(), svc.CreateMessage)
+	app.router.POST("/orders/:id/messages/read", app.AuthRequired(), svc.MarkOrderMessagesRead)
+	app.router.POST("/posts/:id/report", app.AuthRequired(), app.ActiveUserRequired(), svc.CreatePostReport)
+	app.router.POST("/posts/:id/favorite", app.AuthRequired(), svc.AddFavorite)
+	app.router.POST("/orders/:id/reviews", app.AuthRequired(), app.ActiveUserRequired(), svc.CreateReview)
+	app.router.POST("/posts/:id/messages/read", app.AuthRequired(), svc.MarkPostMessagesRead)
+	app.router.POST("/user/devices", app.AuthRequired(), svc.RegisterDevice)
+	app.router.POST("/user/saved-searches", app.AuthRequired(), svc.CreateSavedSearch)
+	app.router.POST("/user/avatar", app.AuthRequired(), svc.UploadAvatar)
+	app.router.POST("/notifications/read", app.AuthRequired(), svc.MarkAllNotificationsRead)
+	app.router.POST("/notifications/:id/read", app.AuthRequired(), svc.MarkNotificationRead)
+
+	app.router.PATCH("/posts/:id", app.AuthRequired(), app.ActiveUserRequired(), svc.UpdatePost)
+	app.router.PATCH("/orders/:id", app.AuthRequired(), svc.UpdateOrder)
+	app.router.PATCH("/user", app.AuthRequired(), svc.UpdateUser)
+	app.router.PATCH("/user/notifications", app.AuthRequired(), svc.UpdateNotificationPreference)
 
-	app.router.POST("/user/signup", svc.UserSignup)
-	app.router.POST("/user/login", svc.UserLogin)
-	app.router.POST("/posts", AuthRequired(), svc.CreatePost)
-	app.router.POST("/orders", AuthRequired(), svc.CreateOrder)
-	app.router.POST("/messages", AuthRequired(), svc.CreateMessage)
+	app.router.DELETE("/posts/:id/favorite", app.AuthRequired(), svc.RemoveFavorite)
+	app.router.DELETE("/user", app.AuthRequired(), svc.DeleteUser)
+	app.router.DELETE("/user/saved-searches/:id", app.AuthRequired(), svc.DeleteSavedSearch)
+	app.router.DELETE("/user/devices/:token", app.AuthRequired(), svc.DeleteDevice)
 
-	app.router.PATCH("/posts/:id", AuthRequired(), svc.UpdatePost)
+	admin := app.router.Group("/admin", app.AuthRequired(), app.RoleRequired(repositories.ROLE_ADMIN))
+	admin.GET("/message-flags", svc.GetMessageFlags)
+	admin.PATCH("/message-flags/:id", svc.UpdateMessageFlag)
+	admin.GET("/reports", svc.GetPostReports)
+	admin.GET("/moderation-logs", svc.GetModerationLogs)
+	admin.POST("/reports/:id/actions", svc.ResolvePostReport)
+	admin.PATCH("/users/:id/role", svc.UpdateUserRole)
+	admin.PATCH("/users/:id/suspension", svc.UpdateUserSuspension)
+	admin.PATCH("/posts/:id/visibility", svc.UpdatePostVisibility)
+	admin.POST("/tag-types", svc.CreateTagType)
+	admin.PATCH("/tag-types/:id", svc.UpdateTagType)
+	admin.POST("/tags", svc.CreateTag)
+	admin.PATCH("/tags/:id", svc.UpdateTag)
+	admin.DELETE("/tags/:id", svc.DeleteTag)
+	admin.POST("/categories", svc.CreateCategory)
+	admin.PATCH("/categories/:id", svc.UpdateCategory)
+	admin.DELETE("/categories/:id", svc.RetireCategory)
 }
 
 func (app *App) Run() {
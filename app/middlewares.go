@@ -1,60 +1,179 @@
 package app
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 
-	firebase "firebase.google.com/go"
-
 	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/verifier"
 )
 
-func AuthRequired() gin.HandlerFunc {
+func (app *App) AuthRequired() gin.HandlerFunc {
+	return authRequired(app.verifier, parseIdToken)
+}
+
+func (app *App) StreamAuthRequired() gin.HandlerFunc {
+	return authRequired(app.verifier, parseStreamIdToken)
+}
+
+func (app *App) AuthOptional() gin.HandlerFunc {
+	required := authRequired(app.verifier, parseIdToken)
+
 	return func(c *gin.Context) {
-		app, err := firebase.NewApp(context.Background(), nil)
+		if c.Request.Header.Get("Authorization") == "" {
+			c.Next()
+			return
+		}
+
+		required(c)
+	}
+}
+
+func (app *App) RoleRequired(roles ...string) gin.HandlerFunc {
+	allowed := map[string]bool{}
+	for idx := range roles {
+		allowed[roles[idx]] = true
+	}
+
+	return func(c *gin.Context) {
+		googleAuthId, ok := c.Get("googleAuthId")
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, "Failed to authorize user")
+			return
+		}
+
+		user, err := app.repo.GetUserByGoogleAuthId(c, googleAuthId)
 		if err != nil {
-			log.Println("Error initializing app |", err)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, err.Error())
+			log.Println("Error getting user for role check |", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, "Something went wrong while authorizing user")
+			return
+		}
+
+		if user == nil || !allowed[user.Role] {
+			c.AbortWithStatusJSON(http.StatusForbidden, "Not allowed to access this resource")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (app *App) ActiveUserRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		googleAuthId, ok := c.Get("googleAuthId")
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, "Failed to authorize user")
 			return
 		}
 
-		idToken, err := parseIdToken(c)
+		user, err := app.repo.GetUserByGoogleAuthId(c, googleAuthId)
 		if err != nil {
-			log.Println("Error parsing ID token |", err)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, err.Error())
+			log.Println("Error getting user for suspension check |", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, "Something went wrong while authorizing user")
+			return
+		}
+
+		if user != nil && user.SuspendedAt != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, "Account is suspended")
 			return
 		}
 
-		client, err := app.Auth(c)
+		c.Next()
+	}
+}
+
+func authRequired(v verifier.Verifier, parse func(c *gin.Context) (*string, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idToken, err := parse(c)
 		if err != nil {
-			log.Println("Error getting Auth client |", err)
+			log.Println("Error parsing ID token |", err)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, err.Error())
 			return
 		}
 
-		token, err := client.VerifyIDToken(c, *idToken)
+		token, err := v.Verify(c, *idToken)
 		if err != nil {
 			log.Println("Error verifying ID token |", err)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, err.Error())
+			c.AbortWithStatusJSON(http.StatusUnauthorized, "Failed to verify ID token")
 			return
 		}
 
-		c.Set("googleAuthId", token.UID)
+		setToken(c, token)
 
 		c.Next()
 	}
 }
 
+func setToken(c *gin.Context, token *verifier.Token) {
+	c.Set("googleAuthId", token.UID)
+
+	if token.Email != "" {
+		c.Set("googleAuthEmail", token.Email)
+		c.Set("googleAuthEmailVerified", token.EmailVerified)
+	}
+}
+
 func parseIdToken(c *gin.Context) (*string, error) {
-	authHeader := c.Request.Header.Get("Authorization")
-	authArr := strings.Split(authHeader, "Bearer ")
+	return parseBearer(c.Request.Header.Get("Authorization"))
+}
+
+func parseBearer(authHeader string) (*string, error) {
+	authArr := strings.SplitN(authHeader, " ", 2)
+	if len(authArr) != 2 || !strings.EqualFold(authArr[0], "Bearer") {
+		return nil, fmt.Errorf("failed to parse Authorization header")
+	}
 
-	if len(authArr) > 1 {
-		return &authArr[1], nil
+	idToken := authArr[1]
+	if idToken == "" || strings.ContainsAny(idToken, " \t\r\n") {
+		return nil, fmt.Errorf("failed to parse Authorization header")
 	}
 
-	return nil, fmt.Errorf("failed to parse Authorization header")
+	return &idToken, nil
+}
+
+func parseStreamIdToken(c *gin.Context) (*string, error) {
+	if c.Request.Header.Get("Authorization") != "" {
+		return parseIdToken(c)
+	}
+
+	if idToken := c.Query("token"); idToken != "" {
+		return &idToken, nil
+	}
+
+	return nil, fmt.Errorf("failed to parse Authorization header or token param")
+}
+
+func redactedLogFormatter(param gin.LogFormatterParams) string {
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		redactPath(param.Path),
+		param.ErrorMessage,
+	)
+}
+
+func redactPath(path string) string {
+	idx := strings.Index(path, "?")
+	if idx < 0 {
+		return path
+	}
+
+	query, err := url.ParseQuery(path[idx+1:])
+	if err != nil {
+		return path[:idx]
+	}
+
+	if query.Get("token") == "" {
+		return path
+	}
+
+	query.Set("token", "REDACTED")
+
+	return path[:idx] + "?" + query.Encode()
 }
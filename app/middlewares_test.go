@@ -0,0 +1,131 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/katakeda/boardhop-api-service-go/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthRequired(t *testing.T) {
+	app := &App{verifier: verifier.NewHMACVerifier([]byte("secret"))}
+
+	router := gin.New()
+	router.GET("/me", app.AuthRequired(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("googleAuthId")+"|"+c.GetString("googleAuthEmail"))
+	})
+
+	idToken, _ := verifier.SignHMAC([]byte("secret"), verifier.Token{UID: "uid-1", Email: "taro@example.com"}, time.Hour)
+
+	cases := []struct {
+		header string
+		status int
+	}{
+		{"Bearer " + idToken, http.StatusOK},
+		{"bearer " + idToken, http.StatusOK},
+		{"", http.StatusUnauthorized},
+		{"Bearer ", http.StatusUnauthorized},
+		{"Token " + idToken, http.StatusUnauthorized},
+		{"xBearer " + idToken, http.StatusUnauthorized},
+		{"Bearer " + idToken + " extra", http.StatusUnauthorized},
+		{"Bearer invalid.token.value", http.StatusUnauthorized},
+	}
+
+	for idx := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", cases[idx].header)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, cases[idx].status, w.Code, cases[idx].header)
+		if cases[idx].status == http.StatusOK {
+			assert.Equal(t, "uid-1|taro@example.com", w.Body.String())
+		}
+	}
+}
+
+func TestRoleRequired(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "admin-uid").
+		Return(&repositories.User{Id: "admin", Role: repositories.ROLE_ADMIN}, nil)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "user-uid").
+		Return(&repositories.User{Id: "user", Role: repositories.ROLE_USER}, nil)
+
+	app := &App{repo: mockRepo}
+
+	for uid, status := range map[string]int{"admin-uid": http.StatusOK, "user-uid": http.StatusForbidden} {
+		router := gin.New()
+		router.GET("/admin", func(c *gin.Context) { c.Set("googleAuthId", uid) }, app.RoleRequired(repositories.ROLE_ADMIN), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+		assert.Equal(t, status, w.Code, uid)
+	}
+}
+
+func TestRedactPath(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"/posts", "/posts"},
+		{"/posts?cats=surf", "/posts?cats=surf"},
+		{"/messages/stream?token=eyJhbGciOi.payload.sig", "/messages/stream?token=REDACTED"},
+		{"/messages/stream?lastEventId=3&token=abc", "/messages/stream?lastEventId=3&token=REDACTED"},
+		{"/messages/stream?token=%zz", "/messages/stream"},
+	}
+
+	for idx := range cases {
+		assert.Equal(t, cases[idx].expected, redactPath(cases[idx].path))
+	}
+}
+
+func TestAuthOptional(t *testing.T) {
+	app := &App{verifier: verifier.NewHMACVerifier([]byte("secret"))}
+
+	router := gin.New()
+	router.GET("/posts", app.AuthOptional(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("googleAuthId"))
+	})
+
+	idToken, _ := verifier.SignHMAC([]byte("secret"), verifier.Token{UID: "uid-1"}, time.Hour)
+	expired, _ := verifier.SignHMAC([]byte("secret"), verifier.Token{UID: "uid-1"}, -time.Minute)
+
+	cases := []struct {
+		header string
+		status int
+		body   string
+	}{
+		{"", http.StatusOK, ""},
+		{"Bearer " + idToken, http.StatusOK, "uid-1"},
+		{"Token " + idToken, http.StatusUnauthorized, ""},
+		{"Bearer " + expired, http.StatusUnauthorized, ""},
+		{"Bearer invalid.token.value", http.StatusUnauthorized, ""},
+	}
+
+	for idx := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+		if cases[idx].header != "" {
+			req.Header.Set("Authorization", cases[idx].header)
+		}
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, cases[idx].status, w.Code, cases[idx].header)
+		if cases[idx].status == http.StatusOK {
+			assert.Equal(t, cases[idx].body, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+type AsyncMailer struct {
+	mailer Mailer
+	queue  chan Email
+}
+
+func NewAsyncMailer(mailer Mailer, workers int, size int) *AsyncMailer {
+	m := &AsyncMailer{
+		mailer: mailer,
+		queue:  make(chan Email, size),
+	}
+
+	for i := 0; i < workers; i++ {
+		go m.work()
+	}
+
+	return m
+}
+
+func (m *AsyncMailer) Send(ctx context.Context, email Email) error {
+	select {
+	case m.queue <- email:
+		return nil
+	default:
+		return fmt.Errorf("mail queue is full, dropping mail to: %s", email.To)
+	}
+}
+
+func (m *AsyncMailer) work() {
+	for email := range m.queue {
+		if err := m.mailer.Send(context.Background(), email); err != nil {
+			log.Println("Failed to send mail |", err)
+		}
+	}
+}
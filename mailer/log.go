@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+type LogMailer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{
+		w: log.Writer(),
+	}
+}
+
+func NewFileMailer(path string) (*LogMailer, error) {
+	if path == "" {
+		return nil, fmt.Errorf("mail sink path is required")
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mail sink: %s | %w", path, err)
+	}
+
+	return &LogMailer{
+		w: f,
+	}, nil
+}
+
+func (m *LogMailer) Send(ctx context.Context, email Email) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := fmt.Fprintf(m.w, "--- mail %s\nTo: %s\nSubject: %s\n\n%s\n",
+		time.Now().Format(time.RFC3339), email.To, email.Subject, email.Body)
+	if err != nil {
+		return fmt.Errorf("failed to write mail to sink | %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+func NewMailer() (Mailer, error) {
+	switch os.Getenv("MAILER") {
+	case "smtp":
+		return NewSMTPMailer(SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     os.Getenv("SMTP_PORT"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("MAIL_FROM"),
+		})
+	case "file":
+		return NewFileMailer(os.Getenv("MAIL_SINK_PATH"))
+	case "", "log":
+		return NewLogMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer: %s", os.Getenv("MAILER"))
+	}
+}
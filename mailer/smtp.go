@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+func NewSMTPMailer(config SMTPConfig) (*SMTPMailer, error) {
+	if config.Host == "" || config.Port == "" || config.From == "" {
+		return nil, fmt.Errorf("smtp host, port and from address are required")
+	}
+
+	return &SMTPMailer{
+		config: config,
+	}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, email Email) error {
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	addr := net.JoinHostPort(m.config.Host, m.config.Port)
+	if err := smtp.SendMail(addr, auth, m.config.From, []string{email.To}, m.buildMessage(email)); err != nil {
+		return fmt.Errorf("failed to send mail to: %s | %w", email.To, err)
+	}
+
+	return nil
+}
+
+func (m *SMTPMailer) buildMessage(email Email) []byte {
+	var msg bytes.Buffer
+	msg.WriteString("From: " + m.config.From + "\r\n")
+	msg.WriteString("To: " + email.To + "\r\n")
+	msg.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", email.Subject) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: base64\r\n")
+	msg.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(email.Body))
+	for len(encoded) > 76 {
+		msg.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	msg.WriteString(encoded + "\r\n")
+
+	return msg.Bytes()
+}
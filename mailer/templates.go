@@ -0,0 +1,65 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	DEFAULT_LOCALE = "ja"
+)
+
+const (
+	TEMPLATE_ORDER_REQUESTED = "order_requested"
+	TEMPLATE_ORDER_ACCEPTED  = "order_accepted"
+	TEMPLATE_ORDER_CANCELED  = "order_canceled"
+	TEMPLATE_PICKUP_REMINDER = "pickup_reminder"
+	TEMPLATE_NEW_MESSAGE     = "new_message"
+	TEMPLATE_REVIEW_REQUEST  = "review_request"
+	TEMPLATE_REVIEW_RECEIVED = "review_received"
+
+	TEMPLATE_SAVED_SEARCH_DIGEST = "saved_search_digest"
+	TEMPLATE_MODERATION_WARNING  = "moderation_warning"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+type TemplateData struct {
+	RecipientName string
+	ActorName     string
+	PostTitle     string
+	StartDate     string
+	EndDate       string
+	Message       string
+	Url           string
+	SearchName    string
+	PostTitles    []string
+}
+
+func Render(locale string, name string, to string, data TemplateData) (*Email, error) {
+	tmpl, err := template.ParseFS(templateFS, fmt.Sprintf("templates/%s/%s.tmpl", locale, name))
+	if err != nil && locale != DEFAULT_LOCALE {
+		tmpl, err = template.ParseFS(templateFS, fmt.Sprintf("templates/%s/%s.tmpl", DEFAULT_LOCALE, name))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %s | %w", name, err)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject: %s | %w", name, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return nil, fmt.Errorf("failed to render body: %s | %w", name, err)
+	}
+
+	return &Email{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	data := TemplateData{
+		RecipientName: "Taro",
+		ActorName:     "Hanako",
+		PostTitle:     "Pyzel Ghost 5'10",
+		StartDate:     "2023-04-01",
+		EndDate:       "2023-04-03",
+		Message:       "Is it available?",
+		SearchName:    "Shortboards near Kamakura",
+		PostTitles:    []string{"Pyzel Ghost 5'10", "JS Monsta 6'0"},
+	}
+
+	names := []string{
+		TEMPLATE_ORDER_REQUESTED,
+		TEMPLATE_ORDER_ACCEPTED,
+		TEMPLATE_ORDER_CANCELED,
+		TEMPLATE_PICKUP_REMINDER,
+		TEMPLATE_NEW_MESSAGE,
+		TEMPLATE_REVIEW_REQUEST,
+		TEMPLATE_REVIEW_RECEIVED,
+		TEMPLATE_SAVED_SEARCH_DIGEST,
+		TEMPLATE_MODERATION_WARNING,
+	}
+
+	for _, locale := range []string{"ja", "en"} {
+		for _, name := range names {
+			email, err := Render(locale, name, "taro@example.com", data)
+			assert.NoError(t, err, locale+"/"+name)
+			assert.Equal(t, "taro@example.com", email.To)
+			assert.NotEmpty(t, email.Subject)
+			assert.Contains(t, email.Body, "Taro")
+		}
+	}
+
+	email, err := Render("fr", TEMPLATE_ORDER_ACCEPTED, "taro@example.com", data)
+	assert.NoError(t, err)
+	assert.Contains(t, email.Subject, "承認")
+}
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_message_created() RETURNS TRIGGER AS $$
+    DECLARE
+        RECIPIENTS uuid[];
+    BEGIN
+        IF NEW.order_id IS NOT NULL THEN
+            SELECT ARRAY[a.user_id, b.user_id] FROM "order" a JOIN post b ON a.post_id = b.id WHERE a.id = NEW.order_id INTO RECIPIENTS;
+        ELSE
+            SELECT array_agg(DISTINCT user_id) FROM (
+                SELECT user_id FROM post WHERE id = NEW.post_id
+                UNION
+                SELECT user_id FROM "order" WHERE post_id = NEW.post_id
+            ) AS parties INTO RECIPIENTS;
+        END IF;
+        PERFORM pg_notify('message_created', json_build_object('id', NEW.id, 'recipients', RECIPIENTS)::text);
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER message_created_trigger
+    AFTER INSERT ON message
+    FOR EACH ROW EXECUTE PROCEDURE notify_message_created();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS "message_created_trigger" ON "message";
+DROP FUNCTION IF EXISTS notify_message_created();
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "message_read" (
+    "id" bigserial NOT NULL,
+    "user_id" uuid NOT NULL,
+    "post_id" uuid,
+    "order_id" uuid,
+    "last_read_message_id" int8 NOT NULL DEFAULT 0,
+    "updated_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user" FOREIGN KEY ("user_id") REFERENCES "user" ("id"),
+    CONSTRAINT "fk_post" FOREIGN KEY ("post_id") REFERENCES "post" ("id"),
+    CONSTRAINT "fk_order" FOREIGN KEY ("order_id") REFERENCES "order" ("id")
+);
+
+CREATE UNIQUE INDEX "message_read_user_order_idx" ON "message_read" ("user_id", "order_id") WHERE "order_id" IS NOT NULL;
+CREATE UNIQUE INDEX "message_read_user_post_idx" ON "message_read" ("user_id", "post_id") WHERE "post_id" IS NOT NULL;
+CREATE INDEX "message_order_id_idx" ON "message" ("order_id", "id");
+CREATE INDEX "message_post_id_idx" ON "message" ("post_id", "id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "message_post_id_idx";
+DROP INDEX IF EXISTS "message_order_id_idx";
+DROP TABLE "message_read";
+-- +goose StatementEnd
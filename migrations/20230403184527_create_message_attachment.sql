@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "message" ALTER COLUMN "message" SET DATA TYPE varchar(2000);
+
+CREATE TABLE "message_attachment" (
+    "id" bigserial NOT NULL,
+    "message_id" int8 NOT NULL,
+    "media_url" text NOT NULL,
+    "type" media_type NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_message" FOREIGN KEY ("message_id") REFERENCES "message" ("id")
+);
+
+CREATE INDEX "message_attachment_message_id_idx" ON "message_attachment" ("message_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "message_attachment";
+ALTER TABLE "message" ALTER COLUMN "message" SET DATA TYPE varchar(255);
+-- +goose StatementEnd
@@ -0,0 +1,7 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'accepted' AFTER 'pending';
+
+-- +goose Down
+-- Postgres cannot drop a value from an enum type
+SELECT 1;
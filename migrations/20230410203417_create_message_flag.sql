@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP TYPE IF EXISTS flag_status;
+
+CREATE TYPE flag_status AS ENUM ('pending', 'dismissed', 'removed');
+
+CREATE TABLE "message_flag" (
+    "id" bigserial NOT NULL,
+    "message_id" int8 NOT NULL,
+    "reason" varchar(255) NOT NULL,
+    "matches" text[] NOT NULL DEFAULT '{}',
+    "status" flag_status NOT NULL DEFAULT 'pending',
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    "resolved_at" timestamp,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_message" FOREIGN KEY ("message_id") REFERENCES "message" ("id")
+);
+
+CREATE INDEX "message_flag_status_idx" ON "message_flag" ("status", "created_at");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "message_flag";
+DROP TYPE IF EXISTS flag_status;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "notification_preference" (
+    "user_id" uuid NOT NULL,
+    "locale" varchar(10) NOT NULL DEFAULT 'ja',
+    "email_orders" boolean NOT NULL DEFAULT TRUE,
+    "email_messages" boolean NOT NULL DEFAULT TRUE,
+    "email_reminders" boolean NOT NULL DEFAULT TRUE,
+    "updated_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("user_id"),
+    CONSTRAINT "fk_user" FOREIGN KEY ("user_id") REFERENCES "user" ("id")
+);
+
+ALTER TABLE "order" ADD COLUMN "reminder_sent_at" timestamp;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "order" DROP COLUMN "reminder_sent_at";
+DROP TABLE "notification_preference";
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP TYPE IF EXISTS device_platform;
+
+CREATE TYPE device_platform AS ENUM ('ios', 'android', 'web');
+
+CREATE TABLE "device_token" (
+    "id" bigserial NOT NULL,
+    "user_id" uuid NOT NULL,
+    "token" text NOT NULL UNIQUE,
+    "platform" device_platform NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    "updated_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user" FOREIGN KEY ("user_id") REFERENCES "user" ("id")
+);
+
+CREATE INDEX "device_token_user_id_idx" ON "device_token" ("user_id");
+
+ALTER TABLE "notification_preference" ADD COLUMN "push_enabled" boolean NOT NULL DEFAULT TRUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "notification_preference" DROP COLUMN "push_enabled";
+DROP TABLE "device_token";
+DROP TYPE IF EXISTS device_platform;
+-- +goose StatementEnd
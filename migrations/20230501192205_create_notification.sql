@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "notification" (
+    "id" bigserial NOT NULL,
+    "user_id" uuid NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "body" text NOT NULL,
+    "target" text,
+    "read_at" timestamp,
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user" FOREIGN KEY ("user_id") REFERENCES "user" ("id")
+);
+
+CREATE INDEX "notification_user_id_idx" ON "notification" ("user_id", "id" DESC);
+CREATE INDEX "notification_unread_idx" ON "notification" ("user_id") WHERE "read_at" IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "notification";
+-- +goose StatementEnd
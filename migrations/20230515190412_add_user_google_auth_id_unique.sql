@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE UNIQUE INDEX "user_google_auth_id_key" ON "user" ("google_auth_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "user_google_auth_id_key";
+-- +goose StatementEnd
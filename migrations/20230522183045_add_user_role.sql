@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP TYPE IF EXISTS user_role;
+
+CREATE TYPE user_role AS ENUM ('user', 'shop_staff', 'admin');
+
+ALTER TABLE "user" ADD COLUMN "role" user_role NOT NULL DEFAULT 'user';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "user" DROP COLUMN "role";
+DROP TYPE IF EXISTS user_role;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP TYPE IF EXISTS review_role;
+
+CREATE TYPE review_role AS ENUM ('renter', 'owner');
+
+ALTER TABLE "order" ADD COLUMN "completed_at" timestamp;
+
+UPDATE "order" SET "completed_at" = NOW() WHERE "status" = 'complete';
+
+CREATE TABLE "review" (
+    "id" bigserial NOT NULL,
+    "order_id" uuid NOT NULL,
+    "post_id" uuid NOT NULL,
+    "reviewer_id" uuid NOT NULL,
+    "reviewee_id" uuid NOT NULL,
+    "role" review_role NOT NULL,
+    "rating" int2 NOT NULL CHECK ("rating" BETWEEN 1 AND 5),
+    "comment" varchar(1000),
+    "post_rating" int2 CHECK ("post_rating" BETWEEN 1 AND 5),
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("id"),
+    UNIQUE ("order_id", "reviewer_id"),
+    CONSTRAINT "fk_order" FOREIGN KEY ("order_id") REFERENCES "order" ("id"),
+    CONSTRAINT "fk_post" FOREIGN KEY ("post_id") REFERENCES "post" ("id"),
+    CONSTRAINT "fk_reviewer" FOREIGN KEY ("reviewer_id") REFERENCES "user" ("id"),
+    CONSTRAINT "fk_reviewee" FOREIGN KEY ("reviewee_id") REFERENCES "user" ("id")
+);
+
+CREATE INDEX "review_post_id_idx" ON "review" ("post_id", "created_at");
+CREATE INDEX "review_reviewee_id_idx" ON "review" ("reviewee_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "review";
+ALTER TABLE "order" DROP COLUMN "completed_at";
+DROP TYPE IF EXISTS review_role;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "favorite" (
+    "user_id" uuid NOT NULL,
+    "post_id" uuid NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("user_id", "post_id"),
+    CONSTRAINT "fk_user" FOREIGN KEY ("user_id") REFERENCES "user" ("id"),
+    CONSTRAINT "fk_post" FOREIGN KEY ("post_id") REFERENCES "post" ("id")
+);
+
+CREATE INDEX "favorite_post_id_idx" ON "favorite" ("post_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "favorite";
+-- +goose StatementEnd
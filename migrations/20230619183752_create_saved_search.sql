@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "saved_search" (
+    "id" bigserial NOT NULL,
+    "user_id" uuid NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "query" text NOT NULL,
+    "last_notified_at" timestamp NOT NULL DEFAULT NOW(),
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user" FOREIGN KEY ("user_id") REFERENCES "user" ("id")
+);
+
+CREATE INDEX "saved_search_user_id_idx" ON "saved_search" ("user_id");
+CREATE INDEX "saved_search_last_notified_at_idx" ON "saved_search" ("last_notified_at");
+
+ALTER TABLE "notification_preference" ADD COLUMN "email_digests" boolean NOT NULL DEFAULT true;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "notification_preference" DROP COLUMN "email_digests";
+DROP TABLE "saved_search";
+-- +goose StatementEnd
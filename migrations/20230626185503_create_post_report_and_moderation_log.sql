@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP TYPE IF EXISTS report_reason;
+DROP TYPE IF EXISTS report_status;
+
+CREATE TYPE report_reason AS ENUM ('spam', 'prohibited_item', 'misleading', 'offensive', 'scam', 'other');
+CREATE TYPE report_status AS ENUM ('pending', 'dismissed', 'actioned');
+
+ALTER TABLE "post" ADD COLUMN "hidden_at" timestamp;
+ALTER TABLE "user" ADD COLUMN "suspended_at" timestamp;
+
+CREATE TABLE "post_report" (
+    "id" bigserial NOT NULL,
+    "post_id" uuid NOT NULL,
+    "reporter_id" uuid NOT NULL,
+    "reason" report_reason NOT NULL,
+    "details" varchar(1000),
+    "status" report_status NOT NULL DEFAULT 'pending',
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    "resolved_at" timestamp,
+    PRIMARY KEY ("id"),
+    UNIQUE ("post_id", "reporter_id"),
+    CONSTRAINT "fk_post" FOREIGN KEY ("post_id") REFERENCES "post" ("id"),
+    CONSTRAINT "fk_reporter" FOREIGN KEY ("reporter_id") REFERENCES "user" ("id")
+);
+
+CREATE INDEX "post_report_status_idx" ON "post_report" ("status", "created_at");
+
+CREATE TABLE "moderation_log" (
+    "id" bigserial NOT NULL,
+    "actor_id" uuid NOT NULL,
+    "action" varchar(50) NOT NULL,
+    "target_type" varchar(50) NOT NULL,
+    "target_id" text NOT NULL,
+    "note" varchar(1000),
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_actor" FOREIGN KEY ("actor_id") REFERENCES "user" ("id")
+);
+
+CREATE INDEX "moderation_log_target_idx" ON "moderation_log" ("target_type", "target_id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "moderation_log";
+DROP TABLE "post_report";
+ALTER TABLE "user" DROP COLUMN "suspended_at";
+ALTER TABLE "post" DROP COLUMN "hidden_at";
+DROP TYPE IF EXISTS report_status;
+DROP TYPE IF EXISTS report_reason;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "tag_type" (
+    "id" bigserial NOT NULL,
+    "value" varchar(255) NOT NULL,
+    "label" varchar(255) NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("id"),
+    UNIQUE ("value")
+);
+
+CREATE TABLE "tag_type_category" (
+    "tag_type_id" int8 NOT NULL,
+    "category_id" int8 NOT NULL,
+    PRIMARY KEY ("tag_type_id", "category_id"),
+    CONSTRAINT "fk_tag_type" FOREIGN KEY ("tag_type_id") REFERENCES "tag_type" ("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_category" FOREIGN KEY ("category_id") REFERENCES "category" ("id")
+);
+
+INSERT INTO "tag_type" ("value", "label")
+SELECT DISTINCT lower(regexp_replace("type", '[^A-Za-z0-9]+', '_', 'g')), "type" FROM "tag";
+
+INSERT INTO "tag_type_category" ("tag_type_id", "category_id")
+SELECT a."id", b."id" FROM "tag_type" a, "category" b
+WHERE (a."value" = 'surfboard_brand' AND b."value" = 'surfboard')
+   OR (a."value" = 'snowboard_brand' AND b."value" = 'snowboard');
+
+ALTER TABLE "tag" ADD COLUMN "type_id" int8;
+UPDATE "tag" SET "type_id" = a."id" FROM "tag_type" a WHERE a."label" = "tag"."type";
+ALTER TABLE "tag" ALTER COLUMN "type_id" SET NOT NULL;
+ALTER TABLE "tag" ADD CONSTRAINT "fk_type" FOREIGN KEY ("type_id") REFERENCES "tag_type" ("id");
+ALTER TABLE "tag" ADD CONSTRAINT "tag_type_id_value_key" UNIQUE ("type_id", "value");
+ALTER TABLE "tag" DROP COLUMN "type";
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "tag" ADD COLUMN "type" varchar(255);
+UPDATE "tag" SET "type" = a."label" FROM "tag_type" a WHERE a."id" = "tag"."type_id";
+ALTER TABLE "tag" ALTER COLUMN "type" SET NOT NULL;
+ALTER TABLE "tag" DROP CONSTRAINT "tag_type_id_value_key";
+ALTER TABLE "tag" DROP CONSTRAINT "fk_type";
+ALTER TABLE "tag" DROP COLUMN "type_id";
+DROP TABLE "tag_type_category";
+DROP TABLE "tag_type";
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "category" ADD COLUMN "retired_at" timestamp;
+
+CREATE UNIQUE INDEX "category_value_idx" ON "category" ("value") WHERE "retired_at" IS NULL;
+
+CREATE OR REPLACE FUNCTION update_category_path() RETURNS TRIGGER AS $$
+    DECLARE
+        NEW_PATH ltree;
+    BEGIN
+        IF NEW.parent_id IS NULL THEN
+            NEW.path = 'root'::ltree;
+        ELSEIF TG_OP = 'INSERT' OR OLD.parent_id IS NULL OR OLD.parent_id != NEW.parent_id THEN
+            SELECT path || id::text FROM category WHERE id = NEW.parent_id INTO NEW_PATH;
+            IF NEW_PATH IS NULL THEN
+                RAISE EXCEPTION 'Invalid parent_id %', NEW.parent_id;
+            END IF;
+            IF TG_OP = 'UPDATE' AND NEW_PATH <@ (OLD.path || OLD.id::text) THEN
+                RAISE EXCEPTION 'Cannot move category % under its own subtree', NEW.id;
+            END IF;
+            NEW.path = NEW_PATH;
+        END IF;
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION update_category_descendant_paths() RETURNS TRIGGER AS $$
+    BEGIN
+        IF NEW.path IS DISTINCT FROM OLD.path THEN
+            UPDATE category SET path = NEW.path || NEW.id::text WHERE parent_id = NEW.id;
+        END IF;
+        RETURN NULL;
+    END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER category_descendant_path_trigger
+    AFTER UPDATE ON category
+    FOR EACH ROW EXECUTE PROCEDURE update_category_descendant_paths();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS "category_descendant_path_trigger" ON "category";
+DROP FUNCTION IF EXISTS update_category_descendant_paths();
+
+CREATE OR REPLACE FUNCTION update_category_path() RETURNS TRIGGER AS $$
+    DECLARE
+        NEW_PATH ltree;
+    BEGIN
+        IF NEW.parent_id IS NULL THEN
+            NEW.path = 'root'::ltree;
+        ELSEIF TG_OP = 'INSERT' OR OLD.parent_id IS NULL OR OLD.parent_id != NEW.parent_id THEN
+            SELECT path || id::text FROM category WHERE id = NEW.parent_id INTO NEW_PATH;
+            IF NEW_PATH IS NULL THEN
+                RAISE EXCEPTION 'Invalid parent_id %', NEW.parent_id;
+            END IF;
+            NEW.path = NEW_PATH;
+        END IF;
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS "category_value_idx";
+ALTER TABLE "category" DROP COLUMN "retired_at";
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "category" ADD COLUMN "labels" jsonb NOT NULL DEFAULT '{}';
+ALTER TABLE "tag" ADD COLUMN "labels" jsonb NOT NULL DEFAULT '{}';
+ALTER TABLE "tag_type" ADD COLUMN "labels" jsonb NOT NULL DEFAULT '{}';
+
+UPDATE "category" SET "labels" = '{"en": "Surfboard"}' WHERE "value" = 'surfboard';
+UPDATE "category" SET "labels" = '{"en": "Snowboard"}' WHERE "value" = 'snowboard';
+UPDATE "category" SET "labels" = '{"en": "Shortboard"}' WHERE "value" = 'shortboard';
+UPDATE "category" SET "labels" = '{"en": "Longboard"}' WHERE "value" = 'longboard';
+UPDATE "tag" SET "labels" = '{"en": "Beginner"}' WHERE "value" = 'beginner';
+UPDATE "tag" SET "labels" = '{"en": "Intermediate"}' WHERE "value" = 'intermediate';
+UPDATE "tag" SET "labels" = '{"en": "Advanced"}' WHERE "value" = 'advanced';
+UPDATE "tag_type" SET "labels" = '{"ja": "スキルレベル"}' WHERE "value" = 'skill_level';
+UPDATE "tag_type" SET "labels" = '{"ja": "サーフボードブランド"}' WHERE "value" = 'surfboard_brand';
+UPDATE "tag_type" SET "labels" = '{"ja": "スノーボードブランド"}' WHERE "value" = 'snowboard_brand';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "tag_type" DROP COLUMN "labels";
+ALTER TABLE "tag" DROP COLUMN "labels";
+ALTER TABLE "category" DROP COLUMN "labels";
+-- +goose StatementEnd
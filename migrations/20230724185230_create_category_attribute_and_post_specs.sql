@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP TYPE IF EXISTS attribute_type;
+
+CREATE TYPE attribute_type AS ENUM ('number', 'text', 'enum');
+
+CREATE TABLE "category_attribute" (
+    "id" bigserial NOT NULL,
+    "category_id" int8 NOT NULL,
+    "key" varchar(50) NOT NULL,
+    "type" attribute_type NOT NULL,
+    "unit" varchar(20),
+    "options" text[],
+    "min" float8,
+    "max" float8,
+    "required" boolean NOT NULL DEFAULT FALSE,
+    "label" varchar(255) NOT NULL,
+    "labels" jsonb NOT NULL DEFAULT '{}',
+    "created_at" timestamp NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("id"),
+    UNIQUE ("category_id", "key"),
+    CONSTRAINT "fk_category" FOREIGN KEY ("category_id") REFERENCES "category" ("id")
+);
+
+ALTER TABLE "post" ADD COLUMN "specs" jsonb NOT NULL DEFAULT '{}';
+
+CREATE INDEX "post_specs_idx" ON "post" USING GIN ("specs");
+
+INSERT INTO "category_attribute" ("category_id", "key", "type", "unit", "options", "min", "max", "label", "labels")
+SELECT a."id", b."key", b."type"::attribute_type, b."unit", b."options", b."min", b."max", b."label", b."labels"::jsonb
+FROM "category" a, (VALUES
+    ('surfboard', 'length', 'number', 'in', NULL::text[], 48, 144, '長さ', '{"en": "Length"}'),
+    ('surfboard', 'width', 'number', 'in', NULL::text[], 14, 26, '幅', '{"en": "Width"}'),
+    ('surfboard', 'thickness', 'number', 'in', NULL::text[], 1.5, 4.5, '厚さ', '{"en": "Thickness"}'),
+    ('surfboard', 'volume', 'number', 'L', NULL::text[], 10, 120, 'ボリューム', '{"en": "Volume"}'),
+    ('surfboard', 'finSetup', 'enum', NULL, ARRAY['single', 'twin', 'thruster', 'quad', 'five'], NULL, NULL, 'フィン構成', '{"en": "Fin setup"}'),
+    ('snowboard', 'length', 'number', 'cm', NULL::text[], 80, 180, '長さ', '{"en": "Length"}'),
+    ('snowboard', 'flex', 'number', NULL, NULL::text[], 1, 10, 'フレックス', '{"en": "Flex"}')
+) AS b("category", "key", "type", "unit", "options", "min", "max", "label", "labels")
+WHERE a."value" = b."category" AND a."retired_at" IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "post_specs_idx";
+ALTER TABLE "post" DROP COLUMN "specs";
+DROP TABLE "category_attribute";
+DROP TYPE IF EXISTS attribute_type;
+-- +goose StatementEnd
@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "message" ADD COLUMN "asker_id" uuid;
+ALTER TABLE "message" ADD CONSTRAINT "fk_asker" FOREIGN KEY ("asker_id") REFERENCES "user" ("id");
+
+UPDATE "message" a SET "asker_id" = a."user_id"
+FROM "post" b
+WHERE a."post_id" = b."id" AND a."user_id" <> b."user_id";
+
+UPDATE "message" a SET "asker_id" = (
+    SELECT c."asker_id" FROM "message" c
+    WHERE c."post_id" = a."post_id" AND c."asker_id" IS NOT NULL AND c."id" < a."id"
+    ORDER BY c."id" DESC
+    LIMIT 1
+)
+FROM "post" b
+WHERE a."post_id" = b."id" AND a."user_id" = b."user_id";
+
+DROP INDEX IF EXISTS "message_post_id_idx";
+CREATE INDEX "message_post_id_idx" ON "message" ("post_id", "asker_id", "id");
+
+ALTER TABLE "message_read" ADD COLUMN "asker_id" uuid;
+ALTER TABLE "message_read" ADD CONSTRAINT "fk_asker" FOREIGN KEY ("asker_id") REFERENCES "user" ("id");
+
+UPDATE "message_read" a SET "asker_id" = a."user_id"
+FROM "post" b
+WHERE a."post_id" = b."id" AND a."user_id" <> b."user_id";
+
+DELETE FROM "message_read" WHERE "post_id" IS NOT NULL AND "asker_id" IS NULL;
+
+DROP INDEX IF EXISTS "message_read_user_post_idx";
+CREATE UNIQUE INDEX "message_read_user_post_idx" ON "message_read" ("user_id", "post_id", "asker_id") WHERE "post_id" IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION notify_message_created() RETURNS TRIGGER AS $$
+    DECLARE
+        RECIPIENTS uuid[];
+    BEGIN
+        IF NEW.order_id IS NOT NULL THEN
+            SELECT ARRAY[a.user_id, b.user_id] FROM "order" a JOIN post b ON a.post_id = b.id WHERE a.id = NEW.order_id INTO RECIPIENTS;
+        ELSE
+            SELECT ARRAY[user_id, NEW.asker_id] FROM post WHERE id = NEW.post_id INTO RECIPIENTS;
+        END IF;
+        PERFORM pg_notify('message_created', json_build_object('id', NEW.id, 'recipients', RECIPIENTS)::text);
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_message_created() RETURNS TRIGGER AS $$
+    DECLARE
+        RECIPIENTS uuid[];
+    BEGIN
+        IF NEW.order_id IS NOT NULL THEN
+            SELECT ARRAY[a.user_id, b.user_id] FROM "order" a JOIN post b ON a.post_id = b.id WHERE a.id = NEW.order_id INTO RECIPIENTS;
+        ELSE
+            SELECT array_agg(DISTINCT user_id) FROM (
+                SELECT user_id FROM post WHERE id = NEW.post_id
+                UNION
+                SELECT user_id FROM "order" WHERE post_id = NEW.post_id
+            ) AS parties INTO RECIPIENTS;
+        END IF;
+        PERFORM pg_notify('message_created', json_build_object('id', NEW.id, 'recipients', RECIPIENTS)::text);
+        RETURN NEW;
+    END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS "message_read_user_post_idx";
+DELETE FROM "message_read" a USING "message_read" b
+WHERE a."post_id" IS NOT NULL AND a."user_id" = b."user_id" AND a."post_id" = b."post_id" AND a."id" < b."id";
+CREATE UNIQUE INDEX "message_read_user_post_idx" ON "message_read" ("user_id", "post_id") WHERE "post_id" IS NOT NULL;
+ALTER TABLE "message_read" DROP COLUMN "asker_id";
+
+DROP INDEX IF EXISTS "message_post_id_idx";
+CREATE INDEX "message_post_id_idx" ON "message" ("post_id", "id");
+ALTER TABLE "message" DROP COLUMN "asker_id";
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE "tag_type"
+SET
+    "labels" = "labels" - 'ja' || jsonb_build_object('en', "label"),
+    "label" = "labels"->>'ja'
+WHERE "labels" ? 'ja';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE "tag_type"
+SET
+    "labels" = "labels" - 'en' || jsonb_build_object('ja', "label"),
+    "label" = "labels"->>'en'
+WHERE "labels" ? 'en';
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE "category_attribute" SET "key" = 'length' || initcap("unit")
+WHERE "key" = 'length' AND "unit" IN ('in', 'cm');
+
+UPDATE "post" a SET "specs" = a."specs" - 'length' || jsonb_build_object(b."key", a."specs"->'length')
+FROM "category_attribute" b
+JOIN "category" c ON b."category_id" = c."id"
+WHERE a."specs" ? 'length'
+    AND b."key" IN ('lengthIn', 'lengthCm')
+    AND EXISTS (
+        SELECT 1 FROM "post_category" d
+        JOIN "category" e ON d."category_id" = e."id"
+        WHERE d."post_id" = a."id" AND (c."path" || c."id"::text) @> (e."path" || e."id"::text)
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE "post" SET "specs" = "specs" - 'lengthIn' || jsonb_build_object('length', "specs"->'lengthIn')
+WHERE "specs" ? 'lengthIn';
+
+UPDATE "post" SET "specs" = "specs" - 'lengthCm' || jsonb_build_object('length', "specs"->'lengthCm')
+WHERE "specs" ? 'lengthCm';
+
+UPDATE "category_attribute" SET "key" = 'length'
+WHERE "key" IN ('lengthIn', 'lengthCm');
+-- +goose StatementEnd
@@ -8,6 +8,8 @@ import (
 	repositories "github.com/katakeda/boardhop-api-service-go/repositories"
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	url "net/url"
 )
 
@@ -16,6 +18,20 @@ type IRepository struct {
 	mock.Mock
 }
 
+// AnonymizeUser provides a mock function with given fields: ctx, id
+func (_m *IRepository) AnonymizeUser(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BeginTxn provides a mock function with given fields: ctx
 func (_m *IRepository) BeginTxn(ctx context.Context) (context.Context, error) {
 	ret := _m.Called(ctx)
@@ -39,6 +55,27 @@ func (_m *IRepository) BeginTxn(ctx context.Context) (context.Context, error) {
 	return r0, r1
 }
 
+// CategoryExists provides a mock function with given fields: ctx, value, excludeId
+func (_m *IRepository) CategoryExists(ctx context.Context, value string, excludeId *int) (bool, error) {
+	ret := _m.Called(ctx, value, excludeId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) bool); ok {
+		r0 = rf(ctx, value, excludeId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = rf(ctx, value, excludeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CommitTxn provides a mock function with given fields: ctx
 func (_m *IRepository) CommitTxn(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -53,6 +90,41 @@ func (_m *IRepository) CommitTxn(ctx context.Context) error {
 	return r0
 }
 
+// CreateCategory provides a mock function with given fields: ctx, payload
+func (_m *IRepository) CreateCategory(ctx context.Context, payload repositories.CreateCategoryPayload) (int, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.CreateCategoryPayload) int); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.CreateCategoryPayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFavorite provides a mock function with given fields: ctx, userId, postId
+func (_m *IRepository) CreateFavorite(ctx context.Context, userId string, postId string) error {
+	ret := _m.Called(ctx, userId, postId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, postId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateMessage provides a mock function with given fields: ctx, payload
 func (_m *IRepository) CreateMessage(ctx context.Context, payload repositories.CreateMessagePayload) (*repositories.Message, error) {
 	ret := _m.Called(ctx, payload)
@@ -76,6 +148,62 @@ func (_m *IRepository) CreateMessage(ctx context.Context, payload repositories.C
 	return r0, r1
 }
 
+// CreateMessageAttachments provides a mock function with given fields: ctx, attachments
+func (_m *IRepository) CreateMessageAttachments(ctx context.Context, attachments []repositories.CreateMessageAttachment) error {
+	ret := _m.Called(ctx, attachments)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []repositories.CreateMessageAttachment) error); ok {
+		r0 = rf(ctx, attachments)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMessageFlag provides a mock function with given fields: ctx, payload
+func (_m *IRepository) CreateMessageFlag(ctx context.Context, payload repositories.CreateMessageFlagPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.CreateMessageFlagPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateModerationLog provides a mock function with given fields: ctx, payload
+func (_m *IRepository) CreateModerationLog(ctx context.Context, payload repositories.CreateModerationLogPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.CreateModerationLogPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateNotification provides a mock function with given fields: ctx, payload
+func (_m *IRepository) CreateNotification(ctx context.Context, payload repositories.CreateNotificationPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.CreateNotificationPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateOrder provides a mock function with given fields: ctx, payload
 func (_m *IRepository) CreateOrder(ctx context.Context, payload repositories.CreateOrderPayload) (*repositories.Order, error) {
 	ret := _m.Called(ctx, payload)
@@ -150,6 +278,29 @@ func (_m *IRepository) CreatePostMedias(ctx context.Context, medias []repositori
 	return r0
 }
 
+// CreatePostReport provides a mock function with given fields: ctx, payload
+func (_m *IRepository) CreatePostReport(ctx context.Context, payload repositories.CreatePostReportPayload) (*repositories.PostReport, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 *repositories.PostReport
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.CreatePostReportPayload) *repositories.PostReport); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.PostReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.CreatePostReportPayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePostTags provides a mock function with given fields: ctx, tags
 func (_m *IRepository) CreatePostTags(ctx context.Context, tags []repositories.CreatePostTag) error {
 	ret := _m.Called(ctx, tags)
@@ -164,6 +315,124 @@ func (_m *IRepository) CreatePostTags(ctx context.Context, tags []repositories.C
 	return r0
 }
 
+// CreateReview provides a mock function with given fields: ctx, payload
+func (_m *IRepository) CreateReview(ctx context.Context, payload repositories.CreateReviewPayload) (*repositories.Review, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 *repositories.Review
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.CreateReviewPayload) *repositories.Review); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.Review)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.CreateReviewPayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSavedSearch provides a mock function with given fields: ctx, payload
+func (_m *IRepository) CreateSavedSearch(ctx context.Context, payload repositories.CreateSavedSearchPayload) (*repositories.SavedSearch, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 *repositories.SavedSearch
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.CreateSavedSearchPayload) *repositories.SavedSearch); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.CreateSavedSearchPayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTag provides a mock function with given fields: ctx, payload
+func (_m *IRepository) CreateTag(ctx context.Context, payload repositories.CreateTagPayload) (int, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.CreateTagPayload) int); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.CreateTagPayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTagType provides a mock function with given fields: ctx, payload
+func (_m *IRepository) CreateTagType(ctx context.Context, payload repositories.CreateTagTypePayload) (*int, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 *int
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.CreateTagTypePayload) *int); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.CreateTagTypePayload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteDeviceTokens provides a mock function with given fields: ctx, userId, tokens
+func (_m *IRepository) DeleteDeviceTokens(ctx context.Context, userId *string, tokens []string) error {
+	ret := _m.Called(ctx, userId, tokens)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *string, []string) error); ok {
+		r0 = rf(ctx, userId, tokens)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFavorite provides a mock function with given fields: ctx, userId, postId
+func (_m *IRepository) DeleteFavorite(ctx context.Context, userId string, postId string) error {
+	ret := _m.Called(ctx, userId, postId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, postId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeletePostCategories provides a mock function with given fields: ctx, id
 func (_m *IRepository) DeletePostCategories(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -206,45 +475,62 @@ func (_m *IRepository) DeletePostTags(ctx context.Context, id string) error {
 	return r0
 }
 
-// GetCategories provides a mock function with given fields: ctx
-func (_m *IRepository) GetCategories(ctx context.Context) ([]repositories.Category, error) {
-	ret := _m.Called(ctx)
+// DeletePostsByUserId provides a mock function with given fields: ctx, userId
+func (_m *IRepository) DeletePostsByUserId(ctx context.Context, userId string) error {
+	ret := _m.Called(ctx, userId)
 
-	var r0 []repositories.Category
-	if rf, ok := ret.Get(0).(func(context.Context) []repositories.Category); ok {
-		r0 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userId)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]repositories.Category)
-		}
+		r0 = ret.Error(0)
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	return r0
+}
+
+// DeleteSavedSearch provides a mock function with given fields: ctx, userId, id
+func (_m *IRepository) DeleteSavedSearch(ctx context.Context, userId string, id int) error {
+	ret := _m.Called(ctx, userId, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, userId, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetOrder provides a mock function with given fields: ctx, id
-func (_m *IRepository) GetOrder(ctx context.Context, id string) (*repositories.Order, error) {
+// DeleteTag provides a mock function with given fields: ctx, id
+func (_m *IRepository) DeleteTag(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
 
-	var r0 *repositories.Order
-	if rf, ok := ret.Get(0).(func(context.Context, string) *repositories.Order); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
 		r0 = rf(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*repositories.Order)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveOrderCount provides a mock function with given fields: ctx, userId
+func (_m *IRepository) GetActiveOrderCount(ctx context.Context, userId string) (int, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+		r1 = rf(ctx, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -252,22 +538,22 @@ func (_m *IRepository) GetOrder(ctx context.Context, id string) (*repositories.O
 	return r0, r1
 }
 
-// GetOrders provides a mock function with given fields: ctx, filter
-func (_m *IRepository) GetOrders(ctx context.Context, filter repositories.GetOrdersFilter) ([]repositories.Order, error) {
-	ret := _m.Called(ctx, filter)
+// GetCategories provides a mock function with given fields: ctx
+func (_m *IRepository) GetCategories(ctx context.Context) ([]repositories.Category, error) {
+	ret := _m.Called(ctx)
 
-	var r0 []repositories.Order
-	if rf, ok := ret.Get(0).(func(context.Context, repositories.GetOrdersFilter) []repositories.Order); ok {
-		r0 = rf(ctx, filter)
+	var r0 []repositories.Category
+	if rf, ok := ret.Get(0).(func(context.Context) []repositories.Category); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]repositories.Order)
+			r0 = ret.Get(0).([]repositories.Category)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, repositories.GetOrdersFilter) error); ok {
-		r1 = rf(ctx, filter)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -275,16 +561,792 @@ func (_m *IRepository) GetOrders(ctx context.Context, filter repositories.GetOrd
 	return r0, r1
 }
 
-// GetPost provides a mock function with given fields: ctx, id
-func (_m *IRepository) GetPost(ctx context.Context, id string) (*repositories.Post, error) {
-	ret := _m.Called(ctx, id)
+// GetCategoriesWithPostCount provides a mock function with given fields: ctx
+func (_m *IRepository) GetCategoriesWithPostCount(ctx context.Context) ([]repositories.Category, error) {
+	ret := _m.Called(ctx)
 
-	var r0 *repositories.Post
+	var r0 []repositories.Category
+	if rf, ok := ret.Get(0).(func(context.Context) []repositories.Category); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCategory provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetCategory(ctx context.Context, id int) (*repositories.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repositories.Category
+	if rf, ok := ret.Get(0).(func(context.Context, int) *repositories.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCategoryAttributes provides a mock function with given fields: ctx, categoryIds
+func (_m *IRepository) GetCategoryAttributes(ctx context.Context, categoryIds []int) ([]repositories.CategoryAttribute, error) {
+	ret := _m.Called(ctx, categoryIds)
+
+	var r0 []repositories.CategoryAttribute
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []repositories.CategoryAttribute); ok {
+		r0 = rf(ctx, categoryIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.CategoryAttribute)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, categoryIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCategoryBreadcrumb provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetCategoryBreadcrumb(ctx context.Context, id int) ([]repositories.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []repositories.Category
+	if rf, ok := ret.Get(0).(func(context.Context, int) []repositories.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCategoryPostCount provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetCategoryPostCount(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceTokens provides a mock function with given fields: ctx, userId
+func (_m *IRepository) GetDeviceTokens(ctx context.Context, userId string) ([]repositories.DeviceToken, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []repositories.DeviceToken
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repositories.DeviceToken); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.DeviceToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueSavedSearches provides a mock function with given fields: ctx, before
+func (_m *IRepository) GetDueSavedSearches(ctx context.Context, before time.Time) ([]repositories.SavedSearch, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 []repositories.SavedSearch
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []repositories.SavedSearch); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFavoritePosts provides a mock function with given fields: ctx, userId, params
+func (_m *IRepository) GetFavoritePosts(ctx context.Context, userId string, params url.Values) ([]repositories.Post, error) {
+	ret := _m.Called(ctx, userId, params)
+
+	var r0 []repositories.Post
+	if rf, ok := ret.Get(0).(func(context.Context, string, url.Values) []repositories.Post); ok {
+		r0 = rf(ctx, userId, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, url.Values) error); ok {
+		r1 = rf(ctx, userId, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFavoritedPostIds provides a mock function with given fields: ctx, userId, postIds
+func (_m *IRepository) GetFavoritedPostIds(ctx context.Context, userId string, postIds []string) ([]string, error) {
+	ret := _m.Called(ctx, userId, postIds)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, userId, postIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, userId, postIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessage provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetMessage(ctx context.Context, id int) (*repositories.Message, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repositories.Message
+	if rf, ok := ret.Get(0).(func(context.Context, int) *repositories.Message); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.Message)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessageFlags provides a mock function with given fields: ctx, params
+func (_m *IRepository) GetMessageFlags(ctx context.Context, params url.Values) ([]repositories.MessageFlag, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []repositories.MessageFlag
+	if rf, ok := ret.Get(0).(func(context.Context, url.Values) []repositories.MessageFlag); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.MessageFlag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, url.Values) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessages provides a mock function with given fields: ctx, filter, params
+func (_m *IRepository) GetMessages(ctx context.Context, filter repositories.GetMessagesFilter, params url.Values) ([]repositories.Message, error) {
+	ret := _m.Called(ctx, filter, params)
+
+	var r0 []repositories.Message
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.GetMessagesFilter, url.Values) []repositories.Message); ok {
+		r0 = rf(ctx, filter, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Message)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.GetMessagesFilter, url.Values) error); ok {
+		r1 = rf(ctx, filter, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetModerationLogs provides a mock function with given fields: ctx, params
+func (_m *IRepository) GetModerationLogs(ctx context.Context, params url.Values) ([]repositories.ModerationLog, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []repositories.ModerationLog
+	if rf, ok := ret.Get(0).(func(context.Context, url.Values) []repositories.ModerationLog); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.ModerationLog)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, url.Values) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotificationPreference provides a mock function with given fields: ctx, userId
+func (_m *IRepository) GetNotificationPreference(ctx context.Context, userId string) (*repositories.NotificationPreference, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *repositories.NotificationPreference
+	if rf, ok := ret.Get(0).(func(context.Context, string) *repositories.NotificationPreference); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.NotificationPreference)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotifications provides a mock function with given fields: ctx, userId, params
+func (_m *IRepository) GetNotifications(ctx context.Context, userId string, params url.Values) ([]repositories.Notification, error) {
+	ret := _m.Called(ctx, userId, params)
+
+	var r0 []repositories.Notification
+	if rf, ok := ret.Get(0).(func(context.Context, string, url.Values) []repositories.Notification); ok {
+		r0 = rf(ctx, userId, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, url.Values) error); ok {
+		r1 = rf(ctx, userId, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrder provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetOrder(ctx context.Context, id string) (*repositories.Order, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repositories.Order
+	if rf, ok := ret.Get(0).(func(context.Context, string) *repositories.Order); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrders provides a mock function with given fields: ctx, filter
+func (_m *IRepository) GetOrders(ctx context.Context, filter repositories.GetOrdersFilter) ([]repositories.Order, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []repositories.Order
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.GetOrdersFilter) []repositories.Order); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.GetOrdersFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPickupReminderOrders provides a mock function with given fields: ctx, before
+func (_m *IRepository) GetPickupReminderOrders(ctx context.Context, before time.Time) ([]repositories.Order, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 []repositories.Order
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []repositories.Order); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPost provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetPost(ctx context.Context, id string) (*repositories.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repositories.Post
 	if rf, ok := ret.Get(0).(func(context.Context, string) *repositories.Post); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*repositories.Post)
+			r0 = ret.Get(0).(*repositories.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostReport provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetPostReport(ctx context.Context, id int) (*repositories.PostReport, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repositories.PostReport
+	if rf, ok := ret.Get(0).(func(context.Context, int) *repositories.PostReport); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.PostReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostReports provides a mock function with given fields: ctx, params
+func (_m *IRepository) GetPostReports(ctx context.Context, params url.Values) ([]repositories.PostReport, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []repositories.PostReport
+	if rf, ok := ret.Get(0).(func(context.Context, url.Values) []repositories.PostReport); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.PostReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, url.Values) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostReportsByReporter provides a mock function with given fields: ctx, reporterId
+func (_m *IRepository) GetPostReportsByReporter(ctx context.Context, reporterId string) ([]repositories.PostReport, error) {
+	ret := _m.Called(ctx, reporterId)
+
+	var r0 []repositories.PostReport
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repositories.PostReport); ok {
+		r0 = rf(ctx, reporterId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.PostReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reporterId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPosts provides a mock function with given fields: ctx, params
+func (_m *IRepository) GetPosts(ctx context.Context, params url.Values) ([]repositories.Post, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []repositories.Post
+	if rf, ok := ret.Get(0).(func(context.Context, url.Values) []repositories.Post); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, url.Values) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicUser provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetPublicUser(ctx context.Context, id string) (*repositories.PublicUser, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repositories.PublicUser
+	if rf, ok := ret.Get(0).(func(context.Context, string) *repositories.PublicUser); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.PublicUser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReviews provides a mock function with given fields: ctx, filter, params
+func (_m *IRepository) GetReviews(ctx context.Context, filter repositories.GetReviewsFilter, params url.Values) ([]repositories.Review, error) {
+	ret := _m.Called(ctx, filter, params)
+
+	var r0 []repositories.Review
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.GetReviewsFilter, url.Values) []repositories.Review); ok {
+		r0 = rf(ctx, filter, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Review)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.GetReviewsFilter, url.Values) error); ok {
+		r1 = rf(ctx, filter, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSavedSearches provides a mock function with given fields: ctx, userId
+func (_m *IRepository) GetSavedSearches(ctx context.Context, userId string) ([]repositories.SavedSearch, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []repositories.SavedSearch
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repositories.SavedSearch); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.SavedSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTag provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetTag(ctx context.Context, id int) (*repositories.Tag, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repositories.Tag
+	if rf, ok := ret.Get(0).(func(context.Context, int) *repositories.Tag); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTagPostCount provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetTagPostCount(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTagType provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetTagType(ctx context.Context, id int) (*repositories.TagType, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repositories.TagType
+	if rf, ok := ret.Get(0).(func(context.Context, int) *repositories.TagType); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.TagType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTagTypes provides a mock function with given fields: ctx
+func (_m *IRepository) GetTagTypes(ctx context.Context) ([]repositories.TagType, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []repositories.TagType
+	if rf, ok := ret.Get(0).(func(context.Context) []repositories.TagType); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.TagType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTags provides a mock function with given fields: ctx, filter
+func (_m *IRepository) GetTags(ctx context.Context, filter repositories.GetTagsFilter) ([]repositories.Tag, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []repositories.Tag
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.GetTagsFilter) []repositories.Tag); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repositories.GetTagsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnreadCounts provides a mock function with given fields: ctx, userId
+func (_m *IRepository) GetUnreadCounts(ctx context.Context, userId string) ([]repositories.UnreadCount, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []repositories.UnreadCount
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repositories.UnreadCount); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.UnreadCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnreadNotificationCount provides a mock function with given fields: ctx, userId
+func (_m *IRepository) GetUnreadNotificationCount(ctx context.Context, userId string) (int, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByGoogleAuthId provides a mock function with given fields: ctx, googleAuthId
+func (_m *IRepository) GetUserByGoogleAuthId(ctx context.Context, googleAuthId interface{}) (*repositories.User, error) {
+	ret := _m.Called(ctx, googleAuthId)
+
+	var r0 *repositories.User
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) *repositories.User); ok {
+		r0 = rf(ctx, googleAuthId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, googleAuthId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserById provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetUserById(ctx context.Context, id string) (*repositories.User, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repositories.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *repositories.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.User)
 		}
 	}
 
@@ -298,13 +1360,13 @@ func (_m *IRepository) GetPost(ctx context.Context, id string) (*repositories.Po
 	return r0, r1
 }
 
-// GetPosts provides a mock function with given fields: ctx, params
-func (_m *IRepository) GetPosts(ctx context.Context, params url.Values) ([]repositories.Post, error) {
-	ret := _m.Called(ctx, params)
+// GetUserPosts provides a mock function with given fields: ctx, userId, params
+func (_m *IRepository) GetUserPosts(ctx context.Context, userId string, params url.Values) ([]repositories.Post, error) {
+	ret := _m.Called(ctx, userId, params)
 
 	var r0 []repositories.Post
-	if rf, ok := ret.Get(0).(func(context.Context, url.Values) []repositories.Post); ok {
-		r0 = rf(ctx, params)
+	if rf, ok := ret.Get(0).(func(context.Context, string, url.Values) []repositories.Post); ok {
+		r0 = rf(ctx, userId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]repositories.Post)
@@ -312,8 +1374,8 @@ func (_m *IRepository) GetPosts(ctx context.Context, params url.Values) ([]repos
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, url.Values) error); ok {
-		r1 = rf(ctx, params)
+	if rf, ok := ret.Get(1).(func(context.Context, string, url.Values) error); ok {
+		r1 = rf(ctx, userId, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -321,22 +1383,76 @@ func (_m *IRepository) GetPosts(ctx context.Context, params url.Values) ([]repos
 	return r0, r1
 }
 
-// GetTags provides a mock function with given fields: ctx, params
-func (_m *IRepository) GetTags(ctx context.Context, params url.Values) ([]repositories.Tag, error) {
-	ret := _m.Called(ctx, params)
+// ListenMessageEvents provides a mock function with given fields: ctx, events
+func (_m *IRepository) ListenMessageEvents(ctx context.Context, events chan<- repositories.MessageEvent) error {
+	ret := _m.Called(ctx, events)
 
-	var r0 []repositories.Tag
-	if rf, ok := ret.Get(0).(func(context.Context, url.Values) []repositories.Tag); ok {
-		r0 = rf(ctx, params)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, chan<- repositories.MessageEvent) error); ok {
+		r0 = rf(ctx, events)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]repositories.Tag)
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LockUsers provides a mock function with given fields: ctx, ids
+func (_m *IRepository) LockUsers(ctx context.Context, ids []string) error {
+	ret := _m.Called(ctx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkMessagesRead provides a mock function with given fields: ctx, payload
+func (_m *IRepository) MarkMessagesRead(ctx context.Context, payload repositories.MarkMessagesReadPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.MarkMessagesReadPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkNotificationsRead provides a mock function with given fields: ctx, userId, id
+func (_m *IRepository) MarkNotificationsRead(ctx context.Context, userId string, id *int) error {
+	ret := _m.Called(ctx, userId, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) error); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkOrderReminded provides a mock function with given fields: ctx, id
+func (_m *IRepository) MarkOrderReminded(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, url.Values) error); ok {
-		r1 = rf(ctx, params)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -344,22 +1460,20 @@ func (_m *IRepository) GetTags(ctx context.Context, params url.Values) ([]reposi
 	return r0, r1
 }
 
-// GetUserByGoogleAuthId provides a mock function with given fields: ctx, googleAuthId
-func (_m *IRepository) GetUserByGoogleAuthId(ctx context.Context, googleAuthId interface{}) (*repositories.User, error) {
-	ret := _m.Called(ctx, googleAuthId)
+// MarkSavedSearchNotified provides a mock function with given fields: ctx, id, last, at
+func (_m *IRepository) MarkSavedSearchNotified(ctx context.Context, id int, last time.Time, at time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, last, at)
 
-	var r0 *repositories.User
-	if rf, ok := ret.Get(0).(func(context.Context, interface{}) *repositories.User); ok {
-		r0 = rf(ctx, googleAuthId)
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, id, last, at)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*repositories.User)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
-		r1 = rf(ctx, googleAuthId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, id, last, at)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -367,6 +1481,76 @@ func (_m *IRepository) GetUserByGoogleAuthId(ctx context.Context, googleAuthId i
 	return r0, r1
 }
 
+// ReassignCategoryPosts provides a mock function with given fields: ctx, fromId, toId
+func (_m *IRepository) ReassignCategoryPosts(ctx context.Context, fromId int, toId int) error {
+	ret := _m.Called(ctx, fromId, toId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, fromId, toId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegisterDeviceToken provides a mock function with given fields: ctx, payload
+func (_m *IRepository) RegisterDeviceToken(ctx context.Context, payload repositories.RegisterDeviceTokenPayload) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.RegisterDeviceTokenPayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveMessage provides a mock function with given fields: ctx, id
+func (_m *IRepository) RemoveMessage(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolvePostReports provides a mock function with given fields: ctx, postId, status
+func (_m *IRepository) ResolvePostReports(ctx context.Context, postId string, status string) error {
+	ret := _m.Called(ctx, postId, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, postId, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetireCategory provides a mock function with given fields: ctx, id
+func (_m *IRepository) RetireCategory(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RollbackTxn provides a mock function with given fields: ctx
 func (_m *IRepository) RollbackTxn(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -381,6 +1565,141 @@ func (_m *IRepository) RollbackTxn(ctx context.Context) error {
 	return r0
 }
 
+// SetPostHidden provides a mock function with given fields: ctx, id, hidden
+func (_m *IRepository) SetPostHidden(ctx context.Context, id string, hidden bool) error {
+	ret := _m.Called(ctx, id, hidden)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, id, hidden)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTagTypeCategories provides a mock function with given fields: ctx, id, categoryIds
+func (_m *IRepository) SetTagTypeCategories(ctx context.Context, id int, categoryIds []int) error {
+	ret := _m.Called(ctx, id, categoryIds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, id, categoryIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserSuspended provides a mock function with given fields: ctx, id, suspended
+func (_m *IRepository) SetUserSuspended(ctx context.Context, id string, suspended bool) error {
+	ret := _m.Called(ctx, id, suspended)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, id, suspended)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TagExists provides a mock function with given fields: ctx, typeId, value, excludeId
+func (_m *IRepository) TagExists(ctx context.Context, typeId int, value string, excludeId *int) (bool, error) {
+	ret := _m.Called(ctx, typeId, value, excludeId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, *int) bool); ok {
+		r0 = rf(ctx, typeId, value, excludeId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, *int) error); ok {
+		r1 = rf(ctx, typeId, value, excludeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCategory provides a mock function with given fields: ctx, id, payload
+func (_m *IRepository) UpdateCategory(ctx context.Context, id int, payload repositories.UpdateCategoryPayload) error {
+	ret := _m.Called(ctx, id, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, repositories.UpdateCategoryPayload) error); ok {
+		r0 = rf(ctx, id, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMessageFlagStatus provides a mock function with given fields: ctx, id, status
+func (_m *IRepository) UpdateMessageFlagStatus(ctx context.Context, id int, status string) (*repositories.MessageFlag, error) {
+	ret := _m.Called(ctx, id, status)
+
+	var r0 *repositories.MessageFlag
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *repositories.MessageFlag); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositories.MessageFlag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateNotificationPreference provides a mock function with given fields: ctx, payload
+func (_m *IRepository) UpdateNotificationPreference(ctx context.Context, payload repositories.UpdateNotificationPreferencePayload) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repositories.UpdateNotificationPreferencePayload) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOrderStatus provides a mock function with given fields: ctx, id, from, to
+func (_m *IRepository) UpdateOrderStatus(ctx context.Context, id string, from string, to string) (bool, error) {
+	ret := _m.Called(ctx, id, from, to)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePost provides a mock function with given fields: ctx, id, payload
 func (_m *IRepository) UpdatePost(ctx context.Context, id string, payload repositories.UpdatePost) (*repositories.Post, error) {
 	ret := _m.Called(ctx, id, payload)
@@ -404,6 +1723,62 @@ func (_m *IRepository) UpdatePost(ctx context.Context, id string, payload reposi
 	return r0, r1
 }
 
+// UpdateTag provides a mock function with given fields: ctx, id, payload
+func (_m *IRepository) UpdateTag(ctx context.Context, id int, payload repositories.UpdateTagPayload) error {
+	ret := _m.Called(ctx, id, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, repositories.UpdateTagPayload) error); ok {
+		r0 = rf(ctx, id, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTagType provides a mock function with given fields: ctx, id, payload
+func (_m *IRepository) UpdateTagType(ctx context.Context, id int, payload repositories.UpdateTagTypePayload) error {
+	ret := _m.Called(ctx, id, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, repositories.UpdateTagTypePayload) error); ok {
+		r0 = rf(ctx, id, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, id, payload
+func (_m *IRepository) UpdateUser(ctx context.Context, id string, payload repositories.UpdateUserPayload) error {
+	ret := _m.Called(ctx, id, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, repositories.UpdateUserPayload) error); ok {
+		r0 = rf(ctx, id, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserRole provides a mock function with given fields: ctx, id, role
+func (_m *IRepository) UpdateUserRole(ctx context.Context, id string, role string) error {
+	ret := _m.Called(ctx, id, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UserSignup provides a mock function with given fields: ctx, payload
 func (_m *IRepository) UserSignup(ctx context.Context, payload repositories.UserSignupPayload) (*repositories.User, error) {
 	ret := _m.Called(ctx, payload)
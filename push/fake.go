@@ -0,0 +1,44 @@
+package push
+
+import (
+	"context"
+	"sync"
+)
+
+type SentMessage struct {
+	Tokens  []string
+	Message Message
+}
+
+type FakeSender struct {
+	mu            sync.Mutex
+	Sent          []SentMessage
+	InvalidTokens map[string]bool
+	Err           error
+}
+
+func NewFakeSender() *FakeSender {
+	return &FakeSender{
+		InvalidTokens: map[string]bool{},
+	}
+}
+
+func (s *FakeSender) Send(ctx context.Context, tokens []string, message Message) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	s.Sent = append(s.Sent, SentMessage{Tokens: tokens, Message: message})
+
+	invalidTokens := []string{}
+	for idx := range tokens {
+		if s.InvalidTokens[tokens[idx]] {
+			invalidTokens = append(invalidTokens, tokens[idx])
+		}
+	}
+
+	return invalidTokens, nil
+}
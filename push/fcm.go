@@ -0,0 +1,68 @@
+package push
+
+import (
+	"context"
+	"fmt"
+
+	firebase "firebase.google.com/go"
+	"firebase.google.com/go/messaging"
+)
+
+const (
+	FCM_MAX_TOKENS = 500
+)
+
+type FCMSender struct {
+	client *messaging.Client
+}
+
+func NewFCMSender(ctx context.Context) (*FCMSender, error) {
+	app, err := firebase.NewApp(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize firebase app | %w", err)
+	}
+
+	client, err := app.Messaging(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messaging client | %w", err)
+	}
+
+	return &FCMSender{
+		client: client,
+	}, nil
+}
+
+func (s *FCMSender) Send(ctx context.Context, tokens []string, message Message) (invalidTokens []string, err error) {
+	invalidTokens = []string{}
+
+	for start := 0; start < len(tokens); start += FCM_MAX_TOKENS {
+		end := start + FCM_MAX_TOKENS
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+
+		batch := tokens[start:end]
+		res, err := s.client.SendMulticast(ctx, &messaging.MulticastMessage{
+			Tokens: batch,
+			Notification: &messaging.Notification{
+				Title: message.Title,
+				Body:  message.Body,
+			},
+			Data: message.Data,
+		})
+		if err != nil {
+			return invalidTokens, fmt.Errorf("failed to send multicast | %w", err)
+		}
+
+		for idx := range res.Responses {
+			if res.Responses[idx].Success {
+				continue
+			}
+			if messaging.IsRegistrationTokenNotRegistered(res.Responses[idx].Error) {
+				invalidTokens = append(invalidTokens, batch[idx])
+			}
+		}
+	}
+
+	return invalidTokens, nil
+}
@@ -0,0 +1,18 @@
+package push
+
+import (
+	"context"
+	"log"
+)
+
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(ctx context.Context, tokens []string, message Message) ([]string, error) {
+	log.Printf("push to %d devices | %s | %s | %v\n", len(tokens), message.Title, message.Body, message.Data)
+
+	return []string{}, nil
+}
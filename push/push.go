@@ -0,0 +1,28 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+type Message struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+type Sender interface {
+	Send(ctx context.Context, tokens []string, message Message) (invalidTokens []string, err error)
+}
+
+func NewSender(ctx context.Context) (Sender, error) {
+	switch os.Getenv("PUSH_SENDER") {
+	case "fcm":
+		return NewFCMSender(ctx)
+	case "", "log":
+		return NewLogSender(), nil
+	default:
+		return nil, fmt.Errorf("unknown push sender: %s", os.Getenv("PUSH_SENDER"))
+	}
+}
@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/pgxscan"
@@ -15,9 +17,77 @@ type Category struct {
 	Path     *string `json:"path" db:"path"`
 	Value    *string `json:"value" db:"value"`
 	Label    *string `json:"label" db:"label"`
+
+	RetiredAt *time.Time `json:"retiredAt,omitempty" db:"retired_at"`
+	PostCount *int       `json:"postCount,omitempty" db:"post_count"`
+}
+
+type CreateCategoryPayload struct {
+	ParentId *int              `json:"parentId"`
+	Value    string            `json:"value" binding:"required"`
+	Label    string            `json:"label" binding:"required"`
+	Labels   map[string]string `json:"labels"`
+}
+
+type UpdateCategoryPayload struct {
+	ParentId *int              `json:"parentId"`
+	Value    *string           `json:"value"`
+	Label    *string           `json:"label"`
+	Labels   map[string]string `json:"labels"`
+
+	MoveToRoot bool `json:"-"`
+}
+
+func (p *UpdateCategoryPayload) UnmarshalJSON(data []byte) error {
+	type payload UpdateCategoryPayload
+	if err := json.Unmarshal(data, (*payload)(p)); err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	_, exists := fields["parentId"]
+	p.MoveToRoot = exists && p.ParentId == nil
+
+	return nil
 }
 
+const (
+	CATEGORY_POST_COUNT = `(
+		SELECT COUNT(DISTINCT pc.post_id) FROM category cd
+		JOIN post_category pc ON cd.id = pc.category_id
+		JOIN post p ON pc.post_id = p.id
+		JOIN "user" u ON p.user_id = u.id
+		WHERE (a.path || a.id::text) @> (cd.path || cd.id::text)
+		AND p.deleted_at IS NULL AND p.hidden_at IS NULL AND u.suspended_at IS NULL
+	) AS post_count`
+)
+
 func (r *Repository) GetCategories(ctx context.Context) (categories []Category, err error) {
+	return r.getCategories(ctx, nil, false)
+}
+
+func (r *Repository) GetCategoriesWithPostCount(ctx context.Context) (categories []Category, err error) {
+	return r.getCategories(ctx, nil, true)
+}
+
+func (r *Repository) GetCategory(ctx context.Context, id int) (category *Category, err error) {
+	categories, err := r.getCategories(ctx, &id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categories) <= 0 {
+		return nil, nil
+	}
+
+	return &categories[0], nil
+}
+
+func (r *Repository) getCategories(ctx context.Context, id *int, withPostCount bool) (categories []Category, err error) {
 	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
 	if !ok || tx == nil {
 		tx, _ = r.db.Begin(ctx)
@@ -30,17 +100,73 @@ func (r *Repository) GetCategories(ctx context.Context) (categories []Category,
 	}
 
 	cols := []string{
-		"id",
-		"parent_id",
-		"path",
-		"value",
-		"label",
+		"a.id",
+		"a.parent_id",
+		"a.path",
+		"a.value",
+		localizedLabel(ctx, "a") + " AS label",
+		"a.retired_at",
+	}
+
+	if withPostCount {
+		cols = append(cols, CATEGORY_POST_COUNT)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		From("category a")
+
+	if id != nil {
+		psql = psql.Where(sq.Eq{"a.id": id})
+	} else {
+		psql = psql.Where("a.retired_at IS NULL")
+	}
+
+	sqlStmt, sqlArgs, err := psql.OrderBy("a.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&categories, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *Repository) GetCategoryBreadcrumb(ctx context.Context, id int) (categories []Category, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"a.id",
+		"a.parent_id",
+		"a.path",
+		"a.value",
+		localizedLabel(ctx, "a") + " AS label",
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
 	sqlStmt, sqlArgs, err := psql.Select(cols...).
-		From("category").
+		From("category a").
+		Join("category b ON (a.path || a.id::text) @> (b.path || b.id::text)").
+		Where(sq.Eq{"b.id": id}).
+		Where("b.retired_at IS NULL").
+		OrderBy("nlevel(a.path)").
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
@@ -57,3 +183,237 @@ func (r *Repository) GetCategories(ctx context.Context) (categories []Category,
 
 	return categories, nil
 }
+
+func (r *Repository) CategoryExists(ctx context.Context, value string, excludeId *int) (exists bool, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("COUNT(*) > 0").
+		From("category").
+		Where(sq.Eq{"value": value}).
+		Where("retired_at IS NULL")
+
+	if excludeId != nil {
+		psql = psql.Where(sq.NotEq{"id": excludeId})
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return exists, nil
+}
+
+func (r *Repository) GetCategoryPostCount(ctx context.Context, id int) (count int, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("COUNT(*)").
+		From("post_category a").
+		Join("post b ON a.post_id = b.id").
+		Where(sq.Eq{"a.category_id": id}).
+		Where("b.deleted_at IS NULL").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return count, nil
+}
+
+func (r *Repository) CreateCategory(ctx context.Context, payload CreateCategoryPayload) (id int, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"parent_id",
+		"value",
+		"label",
+		"labels",
+	}
+
+	vals := []interface{}{
+		payload.ParentId,
+		payload.Value,
+		payload.Label,
+		labelsOrEmpty(payload.Labels),
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("category").
+		Columns(cols...).
+		Values(vals...).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) UpdateCategory(ctx context.Context, id int, payload UpdateCategoryPayload) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update("category").
+		Where(sq.Eq{"id": id})
+
+	if payload.ParentId != nil {
+		psql = psql.Set("parent_id", payload.ParentId)
+	} else if payload.MoveToRoot {
+		psql = psql.Set("parent_id", nil)
+	}
+	if payload.Value != nil {
+		psql = psql.Set("value", payload.Value)
+	}
+	if payload.Label != nil {
+		psql = psql.Set("label", payload.Label)
+	}
+	if payload.Labels != nil {
+		psql = psql.Set("labels", payload.Labels)
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ReassignCategoryPosts(ctx context.Context, fromId int, toId int) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	sqlStmt, sqlArgs, err := psql.Insert("post_category").
+		Columns("post_id", "category_id").
+		Select(sq.Select("post_id").
+			Column(sq.Expr("?::int8", toId)).
+			From("post_category").
+			Where(sq.Eq{"category_id": fromId})).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	sqlStmt, sqlArgs, err = psql.Delete("post_category").
+		Where(sq.Eq{"category_id": fromId}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RetireCategory(ctx context.Context, id int) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	sqlStmt, sqlArgs, err := psql.Delete("tag_type_category").
+		Where(sq.Eq{"category_id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	sqlStmt, sqlArgs, err = psql.Update("category").
+		Set("retired_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
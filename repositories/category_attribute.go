@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	ATTRIBUTE_NUMBER = "number"
+	ATTRIBUTE_TEXT   = "text"
+	ATTRIBUTE_ENUM   = "enum"
+)
+
+type CategoryAttribute struct {
+	Id         int      `json:"id" db:"id"`
+	CategoryId int      `json:"categoryId" db:"category_id"`
+	Key        string   `json:"key" db:"key"`
+	Type       string   `json:"type" db:"type"`
+	Unit       *string  `json:"unit" db:"unit"`
+	Options    []string `json:"options" db:"options"`
+	Min        *float64 `json:"min" db:"min"`
+	Max        *float64 `json:"max" db:"max"`
+	Required   bool     `json:"required" db:"required"`
+	Label      string   `json:"label" db:"label"`
+}
+
+func (r *Repository) GetCategoryAttributes(ctx context.Context, categoryIds []int) (attributes []CategoryAttribute, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"a.id",
+		"a.category_id",
+		"a.key",
+		"a.type",
+		"a.unit",
+		"a.options",
+		"a.min",
+		"a.max",
+		"a.required",
+		localizedLabel(ctx, "a") + " AS label",
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		Options("DISTINCT ON (a.key)").
+		From("category_attribute a").
+		Join("category b ON a.category_id = b.id").
+		Join("category c ON (b.path || b.id::text) @> (c.path || c.id::text)").
+		Where("c.id = ANY(?)", categoryIds).
+		OrderBy("a.key", "nlevel(b.path) DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&attributes, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return attributes, nil
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var (
+	LOCALE_PATTERN = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, LocaleKey, locale)
+}
+
+func localizedLabel(ctx context.Context, alias string) string {
+	locale, _ := ctx.Value(LocaleKey).(string)
+	if !LOCALE_PATTERN.MatchString(locale) {
+		return alias + ".label"
+	}
+
+	return fmt.Sprintf("COALESCE(%[1]s.labels->>'%[2]s', %[1]s.label)", alias, locale)
+}
+
+// tagTypeName is the English tag type name clients have always grouped tags by.
+func tagTypeName(alias string) string {
+	return fmt.Sprintf("COALESCE(%[1]s.labels->>'en', %[1]s.label)", alias)
+}
+
+func labelsOrEmpty(labels map[string]string) map[string]string {
+	if labels == nil {
+		return map[string]string{}
+	}
+
+	return labels
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -12,7 +14,12 @@ import (
 type CtxKey string
 
 const (
-	TxnKey CtxKey = "txnKey"
+	TxnKey    CtxKey = "txnKey"
+	LocaleKey CtxKey = "localeKey"
+)
+
+const (
+	DEFAULT_LIMIT = 50
 )
 
 type IRepository interface {
@@ -24,6 +31,18 @@ type IRepository interface {
 	GetPost(ctx context.Context, id string) (*Post, error)
 	CreatePost(ctx context.Context, payload CreatePost) (*Post, error)
 	UpdatePost(ctx context.Context, id string, payload UpdatePost) (*Post, error)
+	DeletePostsByUserId(ctx context.Context, userId string) error
+	SetPostHidden(ctx context.Context, id string, hidden bool) error
+	GetFavoritePosts(ctx context.Context, userId string, params url.Values) ([]Post, error)
+	GetUserPosts(ctx context.Context, userId string, params url.Values) ([]Post, error)
+	GetFavoritedPostIds(ctx context.Context, userId string, postIds []string) ([]string, error)
+	GetSavedSearches(ctx context.Context, userId string) ([]SavedSearch, error)
+	GetDueSavedSearches(ctx context.Context, before time.Time) ([]SavedSearch, error)
+	CreateSavedSearch(ctx context.Context, payload CreateSavedSearchPayload) (*SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, userId string, id int) error
+	MarkSavedSearchNotified(ctx context.Context, id int, last time.Time, at time.Time) (bool, error)
+	CreateFavorite(ctx context.Context, userId string, postId string) error
+	DeleteFavorite(ctx context.Context, userId string, postId string) error
 	CreatePostTags(ctx context.Context, tags []CreatePostTag) error
 	CreatePostMedias(ctx context.Context, medias []CreatePostMedia) error
 	CreatePostCategories(ctx context.Context, categories []CreatePostCategory) error
@@ -31,18 +50,80 @@ type IRepository interface {
 	DeletePostMedias(ctx context.Context, id string) error
 	DeletePostCategories(ctx context.Context, id string) error
 
-	GetTags(ctx context.Context, params url.Values) ([]Tag, error)
+	GetTags(ctx context.Context, filter GetTagsFilter) ([]Tag, error)
+	GetTag(ctx context.Context, id int) (*Tag, error)
+	TagExists(ctx context.Context, typeId int, value string, excludeId *int) (bool, error)
+	GetTagPostCount(ctx context.Context, id int) (int, error)
+	CreateTag(ctx context.Context, payload CreateTagPayload) (int, error)
+	UpdateTag(ctx context.Context, id int, payload UpdateTagPayload) error
+	DeleteTag(ctx context.Context, id int) error
+	GetTagTypes(ctx context.Context) ([]TagType, error)
+	GetTagType(ctx context.Context, id int) (*TagType, error)
+	CreateTagType(ctx context.Context, payload CreateTagTypePayload) (*int, error)
+	UpdateTagType(ctx context.Context, id int, payload UpdateTagTypePayload) error
+	SetTagTypeCategories(ctx context.Context, id int, categoryIds []int) error
 
 	GetCategories(ctx context.Context) ([]Category, error)
+	GetCategoriesWithPostCount(ctx context.Context) ([]Category, error)
+	GetCategoryBreadcrumb(ctx context.Context, id int) ([]Category, error)
+	GetCategory(ctx context.Context, id int) (*Category, error)
+	GetCategoryAttributes(ctx context.Context, categoryIds []int) ([]CategoryAttribute, error)
+	CategoryExists(ctx context.Context, value string, excludeId *int) (bool, error)
+	GetCategoryPostCount(ctx context.Context, id int) (int, error)
+	CreateCategory(ctx context.Context, payload CreateCategoryPayload) (int, error)
+	UpdateCategory(ctx context.Context, id int, payload UpdateCategoryPayload) error
+	ReassignCategoryPosts(ctx context.Context, fromId int, toId int) error
+	RetireCategory(ctx context.Context, id int) error
 
 	UserSignup(ctx context.Context, payload UserSignupPayload) (*User, error)
 	GetUserByGoogleAuthId(ctx context.Context, googleAuthId interface{}) (*User, error)
-
+	GetUserById(ctx context.Context, id string) (*User, error)
+	GetPublicUser(ctx context.Context, id string) (*PublicUser, error)
+	UpdateUser(ctx context.Context, id string, payload UpdateUserPayload) error
+	UpdateUserRole(ctx context.Context, id string, role string) error
+	SetUserSuspended(ctx context.Context, id string, suspended bool) error
+	AnonymizeUser(ctx context.Context, id string) error
+	LockUsers(ctx context.Context, ids []string) error
+	GetNotificationPreference(ctx context.Context, userId string) (*NotificationPreference, error)
+	UpdateNotificationPreference(ctx context.Context, payload UpdateNotificationPreferencePayload) error
+	RegisterDeviceToken(ctx context.Context, payload RegisterDeviceTokenPayload) error
+	GetDeviceTokens(ctx context.Context, userId string) ([]DeviceToken, error)
+	DeleteDeviceTokens(ctx context.Context, userId *string, tokens []string) error
+
+	CreateNotification(ctx context.Context, payload CreateNotificationPayload) error
+	GetNotifications(ctx context.Context, userId string, params url.Values) ([]Notification, error)
+	GetUnreadNotificationCount(ctx context.Context, userId string) (int, error)
+	MarkNotificationsRead(ctx context.Context, userId string, id *int) error
+
+	GetReviews(ctx context.Context, filter GetReviewsFilter, params url.Values) ([]Review, error)
+	CreateReview(ctx context.Context, payload CreateReviewPayload) (*Review, error)
+	GetActiveOrderCount(ctx context.Context, userId string) (int, error)
 	GetOrders(ctx context.Context, filter GetOrdersFilter) ([]Order, error)
 	GetOrder(ctx context.Context, id string) (*Order, error)
 	CreateOrder(ctx context.Context, payload CreateOrderPayload) (*Order, error)
+	UpdateOrderStatus(ctx context.Context, id string, from string, to string) (bool, error)
+	GetPickupReminderOrders(ctx context.Context, before time.Time) ([]Order, error)
+	MarkOrderReminded(ctx context.Context, id string) (bool, error)
 
+	GetMessage(ctx context.Context, id int) (*Message, error)
+	GetMessages(ctx context.Context, filter GetMessagesFilter, params url.Values) ([]Message, error)
 	CreateMessage(ctx context.Context, payload CreateMessagePayload) (*Message, error)
+	CreateMessageAttachments(ctx context.Context, attachments []CreateMessageAttachment) error
+	GetMessageFlags(ctx context.Context, params url.Values) ([]MessageFlag, error)
+	UpdateMessageFlagStatus(ctx context.Context, id int, status string) (*MessageFlag, error)
+	RemoveMessage(ctx context.Context, id int) error
+	CreateMessageFlag(ctx context.Context, payload CreateMessageFlagPayload) error
+	ListenMessageEvents(ctx context.Context, events chan<- MessageEvent) error
+	MarkMessagesRead(ctx context.Context, payload MarkMessagesReadPayload) error
+	GetUnreadCounts(ctx context.Context, userId string) ([]UnreadCount, error)
+
+	GetPostReports(ctx context.Context, params url.Values) ([]PostReport, error)
+	GetPostReportsByReporter(ctx context.Context, reporterId string) ([]PostReport, error)
+	GetPostReport(ctx context.Context, id int) (*PostReport, error)
+	CreatePostReport(ctx context.Context, payload CreatePostReportPayload) (*PostReport, error)
+	ResolvePostReports(ctx context.Context, postId string, status string) error
+	GetModerationLogs(ctx context.Context, params url.Values) ([]ModerationLog, error)
+	CreateModerationLog(ctx context.Context, payload CreateModerationLogPayload) error
 }
 
 type Repository struct {
@@ -85,3 +166,21 @@ func (r Repository) RollbackTxn(ctx context.Context) error {
 
 	return tx.(pgx.Tx).Rollback(ctx)
 }
+
+func getPagination(params url.Values) (offset uint64, limit uint64) {
+	page := 0
+	if p := params.Get("p"); p != "" {
+		if v, err := strconv.Atoi(p); err == nil && v >= 0 {
+			page = v
+		}
+	}
+
+	size := DEFAULT_LIMIT
+	if l := params.Get("l"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			size = v
+		}
+	}
+
+	return uint64(page) * uint64(size), uint64(size)
+}
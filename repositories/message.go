@@ -2,7 +2,10 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"mime/multipart"
+	"net/url"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -14,26 +17,76 @@ type Message struct {
 	Id        int        `json:"id" db:"id"`
 	UserId    string     `json:"userId" db:"user_id"`
 	PostId    *string    `json:"postId" db:"post_id"`
+	AskerId   *string    `json:"askerId" db:"asker_id"`
 	OrderId   *string    `json:"orderId" db:"order_id"`
 	Message   *string    `json:"message" db:"message"`
 	CreatedAt *time.Time `json:"createdAt" db:"created_at"`
 
-	AvatarUrl *string `json:"avatarUrl" db:"avatar_url"`
+	AvatarUrl   *string             `json:"avatarUrl" db:"avatar_url"`
+	Attachments []MessageAttachment `json:"attachments" db:"attachments"`
+}
+
+type MessageAttachment struct {
+	Id        int        `json:"id" db:"id"`
+	MessageId int        `json:"messageId" db:"message_id"`
+	MediaUrl  string     `json:"mediaUrl" db:"media_url"`
+	Type      string     `json:"type" db:"type"`
+	CreatedAt *time.Time `json:"createdAt" db:"created_at"`
+}
+
+type MessageEvent struct {
+	Id         int      `json:"id"`
+	Recipients []string `json:"recipients"`
+}
+
+type GetMessagesFilter struct {
+	Id      *int
+	UserId  *string
+	PostId  *string
+	AskerId *string
+	OrderId *string
 }
 
 type CreateMessagePayload struct {
-	UserId  string
-	PostId  *string `json:"postId"`
-	OrderId *string `json:"orderId"`
-	Message *string `json:"message"`
+	UserId      string
+	PostId      *string                 `json:"postId" form:"postId"`
+	AskerId     *string                 `json:"askerId" form:"askerId"`
+	OrderId     *string                 `json:"orderId" form:"orderId"`
+	Message     *string                 `json:"message" form:"message"`
+	Attachments []*multipart.FileHeader `json:"-" form:"attachments"`
 }
 
-func (r *Repository) GetMessagesByOrderId(ctx context.Context, orderId string) (messages []Message, err error) {
-	return r.getMessages(ctx, orderId, "order")
+type CreateMessageFlagPayload struct {
+	MessageId int
+	Reason    string
+	Matches   []string
+}
+
+type CreateMessageAttachment struct {
+	MessageId int
+	MediaUrl  string
+	Type      string
+}
+
+func (r *Repository) GetMessages(ctx context.Context, filter GetMessagesFilter, params url.Values) (messages []Message, err error) {
+	return r.getMessages(ctx, filter, params)
+}
+
+func (r *Repository) GetMessage(ctx context.Context, id int) (message *Message, err error) {
+	messages, err := r.getMessages(ctx, GetMessagesFilter{Id: &id}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) <= 0 {
+		return nil, nil
+	}
+
+	return &messages[0], nil
 }
 
-func (r *Repository) GetMessagesByPostId(ctx context.Context, postId string) (messages []Message, err error) {
-	return r.getMessages(ctx, postId, "post")
+func (r *Repository) GetMessagesByOrderId(ctx context.Context, orderId string) (messages []Message, err error) {
+	return r.getMessages(ctx, GetMessagesFilter{OrderId: &orderId}, nil)
 }
 
 func (r *Repository) CreateMessage(ctx context.Context, payload CreateMessagePayload) (message *Message, err error) {
@@ -51,6 +104,7 @@ func (r *Repository) CreateMessage(ctx context.Context, payload CreateMessagePay
 	cols := []string{
 		"user_id",
 		"post_id",
+		"asker_id",
 		"order_id",
 		"message",
 	}
@@ -58,6 +112,7 @@ func (r *Repository) CreateMessage(ctx context.Context, payload CreateMessagePay
 	vals := []interface{}{
 		payload.UserId,
 		payload.PostId,
+		payload.AskerId,
 		payload.OrderId,
 		payload.Message,
 	}
@@ -80,7 +135,7 @@ func (r *Repository) CreateMessage(ctx context.Context, payload CreateMessagePay
 	return &newMessage, nil
 }
 
-func (r *Repository) getMessages(ctx context.Context, id string, by string) (messages []Message, err error) {
+func (r *Repository) getMessages(ctx context.Context, filter GetMessagesFilter, params url.Values) (messages []Message, err error) {
 	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
 	if !ok || tx == nil {
 		tx, _ = r.db.Begin(ctx)
@@ -96,6 +151,7 @@ func (r *Repository) getMessages(ctx context.Context, id string, by string) (mes
 		"a.id",
 		"a.user_id",
 		"a.post_id",
+		"a.asker_id",
 		"a.order_id",
 		"a.message",
 		"a.created_at",
@@ -107,10 +163,39 @@ func (r *Repository) getMessages(ctx context.Context, id string, by string) (mes
 		From("message a").
 		Join(`"user" b ON a.user_id = b.id`)
 
-	if by == "order" {
-		psql = psql.Where(sq.Eq{"a.order_id": id})
+	if filter.Id != nil {
+		psql = psql.Where(sq.Eq{"a.id": filter.Id})
+	}
+
+	if filter.UserId != nil {
+		psql = psql.Where(sq.Eq{"a.user_id": filter.UserId})
+	}
+
+	if filter.OrderId != nil {
+		psql = psql.Where(sq.Eq{"a.order_id": filter.OrderId})
+	}
+
+	if filter.PostId != nil {
+		psql = psql.Where(sq.Eq{"a.post_id": filter.PostId})
+	}
+
+	if filter.AskerId != nil {
+		psql = psql.Where(sq.Eq{"a.asker_id": filter.AskerId})
+	}
+
+	if filter.Id == nil && filter.UserId == nil && filter.OrderId == nil && filter.PostId == nil {
+		return nil, fmt.Errorf("id, userId, orderId or postId is required")
+	}
+
+	if params.Get("sort") == "newest" {
+		psql = psql.OrderBy("a.created_at DESC", "a.id DESC")
 	} else {
-		psql = psql.Where(sq.Eq{"a.post_id": id})
+		psql = psql.OrderBy("a.created_at ASC", "a.id ASC")
+	}
+
+	if params != nil {
+		offset, limit := getPagination(params)
+		psql = psql.Offset(offset).Limit(limit)
 	}
 
 	sqlStmt, sqlArgs, err := psql.ToSql()
@@ -127,5 +212,180 @@ func (r *Repository) getMessages(ctx context.Context, id string, by string) (mes
 		return nil, fmt.Errorf("failed to scan rows | %w", err)
 	}
 
+	if err := r.setMessageAttachments(ctx, messages); err != nil {
+		return nil, fmt.Errorf("failed to set message attachments | %w", err)
+	}
+
 	return messages, nil
 }
+
+func (r *Repository) CreateMessageAttachments(ctx context.Context, attachments []CreateMessageAttachment) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"message_id",
+		"media_url",
+		"type",
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("message_attachment").
+		Columns(cols...)
+	for idx := range attachments {
+		psql = psql.Values(
+			attachments[idx].MessageId,
+			attachments[idx].MediaUrl,
+			attachments[idx].Type,
+		)
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) setMessageAttachments(ctx context.Context, messages []Message) (err error) {
+	if len(messages) <= 0 {
+		return nil
+	}
+
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	ids := make([]int, len(messages))
+	for idx := range messages {
+		ids[idx] = messages[idx].Id
+	}
+
+	cols := []string{
+		"id",
+		"message_id",
+		"media_url",
+		"type",
+		"created_at",
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	sqlStmt, sqlArgs, err := psql.Select(cols...).
+		From("message_attachment").
+		Where(sq.Eq{"message_id": ids}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	attachments := []MessageAttachment{}
+	if err := pgxscan.ScanAll(&attachments, rows); err != nil {
+		return fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	byMessage := map[int][]MessageAttachment{}
+	for idx := range attachments {
+		byMessage[attachments[idx].MessageId] = append(byMessage[attachments[idx].MessageId], attachments[idx])
+	}
+
+	for idx := range messages {
+		messages[idx].Attachments = byMessage[messages[idx].Id]
+		if messages[idx].Attachments == nil {
+			messages[idx].Attachments = []MessageAttachment{}
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) ListenMessageEvents(ctx context.Context, events chan<- MessageEvent) error {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection | %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN message_created"); err != nil {
+		return fmt.Errorf("failed to listen on message_created | %w", err)
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification | %w", err)
+		}
+
+		var event MessageEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			return fmt.Errorf("failed to parse notification: %s | %w", notification.Payload, err)
+		}
+
+		events <- event
+	}
+}
+
+func (r *Repository) CreateMessageFlag(ctx context.Context, payload CreateMessageFlagPayload) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"message_id",
+		"reason",
+		"matches",
+	}
+
+	vals := []interface{}{
+		payload.MessageId,
+		payload.Reason,
+		payload.Matches,
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("message_flag").
+		Columns(cols...).
+		Values(vals...).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	FLAG_STATUSES = map[string]bool{
+		"pending":   true,
+		"dismissed": true,
+		"removed":   true,
+	}
+)
+
+type MessageFlag struct {
+	Id         int        `json:"id" db:"id"`
+	MessageId  int        `json:"messageId" db:"message_id"`
+	Reason     string     `json:"reason" db:"reason"`
+	Matches    []string   `json:"matches" db:"matches"`
+	Status     string     `json:"status" db:"status"`
+	CreatedAt  *time.Time `json:"createdAt" db:"created_at"`
+	ResolvedAt *time.Time `json:"resolvedAt" db:"resolved_at"`
+
+	UserId  string  `json:"userId" db:"user_id"`
+	PostId  *string `json:"postId" db:"post_id"`
+	OrderId *string `json:"orderId" db:"order_id"`
+	Message *string `json:"message" db:"message"`
+}
+
+type UpdateMessageFlagPayload struct {
+	Status string `json:"status" binding:"required"`
+}
+
+func (r *Repository) GetMessageFlags(ctx context.Context, params url.Values) (flags []MessageFlag, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"a.id",
+		"a.message_id",
+		"a.reason",
+		"a.matches",
+		"a.status",
+		"a.created_at",
+		"a.resolved_at",
+		"b.user_id",
+		"b.post_id",
+		"b.order_id",
+		"b.message",
+	}
+
+	status := params.Get("status")
+	if status == "" {
+		status = "pending"
+	}
+
+	offset, limit := getPagination(params)
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		From("message_flag a").
+		Join("message b ON a.message_id = b.id").
+		Where(sq.Eq{"a.status": status}).
+		OrderBy("a.created_at ASC", "a.id ASC").
+		Offset(offset).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&flags, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return flags, nil
+}
+
+func (r *Repository) UpdateMessageFlagStatus(ctx context.Context, id int, status string) (flag *MessageFlag, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	resolvedAt := sq.Expr("NOW()")
+	if status == "pending" {
+		resolvedAt = sq.Expr("NULL")
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update("message_flag").
+		Set("status", status).
+		Set("resolved_at", resolvedAt).
+		Where(sq.Eq{"id": id}).
+		Suffix("RETURNING id, message_id, reason, matches, status, created_at, resolved_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	var f MessageFlag
+	if err := pgxscan.ScanOne(&f, rows); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return &f, nil
+}
+
+func (r *Repository) RemoveMessage(ctx context.Context, id int) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	sqlStmt, sqlArgs, err := psql.Update("message").
+		Set("message", nil).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	sqlStmt, sqlArgs, err = psql.Delete("message_attachment").
+		Where(sq.Eq{"message_id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+type UnreadCount struct {
+	PostId  *string `json:"postId" db:"post_id"`
+	AskerId *string `json:"askerId" db:"asker_id"`
+	OrderId *string `json:"orderId" db:"order_id"`
+	Count   int     `json:"count" db:"count"`
+}
+
+type MarkMessagesReadPayload struct {
+	UserId    string
+	PostId    *string
+	AskerId   *string
+	OrderId   *string
+	MessageId *int `json:"messageId"`
+}
+
+func (r *Repository) MarkMessagesRead(ctx context.Context, payload MarkMessagesReadPayload) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	if payload.OrderId == nil && (payload.PostId == nil || payload.AskerId == nil) {
+		return fmt.Errorf("orderId or postId and askerId is required")
+	}
+
+	lastRead := sq.Expr("COALESCE(max(id), 0)")
+	if payload.MessageId != nil {
+		lastRead = sq.Expr("LEAST(?::int8, COALESCE(max(id), 0))", payload.MessageId)
+	}
+
+	cols := []string{"user_id", "order_id", "last_read_message_id"}
+	thread := sq.Select().
+		Column(sq.Expr("?::uuid", payload.UserId)).
+		Column(sq.Expr("?::uuid", payload.OrderId))
+	where := sq.Eq{"order_id": payload.OrderId}
+	conflict := "(user_id, order_id) WHERE order_id IS NOT NULL"
+	if payload.OrderId == nil {
+		cols = []string{"user_id", "post_id", "asker_id", "last_read_message_id"}
+		thread = sq.Select().
+			Column(sq.Expr("?::uuid", payload.UserId)).
+			Column(sq.Expr("?::uuid", payload.PostId)).
+			Column(sq.Expr("?::uuid", payload.AskerId))
+		where = sq.Eq{"post_id": payload.PostId, "asker_id": payload.AskerId}
+		conflict = "(user_id, post_id, asker_id) WHERE post_id IS NOT NULL"
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("message_read").
+		Columns(cols...).
+		Select(thread.
+			Column(lastRead).
+			From("message").
+			Where(where)).
+		Suffix("ON CONFLICT " + conflict + " DO UPDATE SET " +
+			"last_read_message_id = GREATEST(message_read.last_read_message_id, EXCLUDED.last_read_message_id), " +
+			"updated_at = NOW()").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetUnreadCounts(ctx context.Context, userId string) (counts []UnreadCount, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	orderCounts := sq.Select("NULL::uuid AS post_id", "NULL::uuid AS asker_id", "a.order_id", "count(*) AS count").
+		From("message a").
+		Join(`"order" b ON a.order_id = b.id`).
+		Join("post c ON b.post_id = c.id").
+		LeftJoin("message_read d ON d.order_id = a.order_id AND d.user_id = ?", userId).
+		Where(sq.Or{sq.Eq{"b.user_id": userId}, sq.Eq{"c.user_id": userId}}).
+		Where(sq.NotEq{"a.user_id": userId}).
+		Where("a.id > COALESCE(d.last_read_message_id, 0)").
+		GroupBy("a.order_id")
+
+	postCounts := sq.Select("a.post_id", "a.asker_id", "NULL::uuid AS order_id", "count(*) AS count").
+		From("message a").
+		Join("post b ON a.post_id = b.id").
+		LeftJoin("message_read c ON c.post_id = a.post_id AND c.asker_id = a.asker_id AND c.user_id = ?", userId).
+		Where(sq.Or{sq.Eq{"b.user_id": userId}, sq.Eq{"a.asker_id": userId}}).
+		Where(sq.NotEq{"a.user_id": userId}).
+		Where("a.id > COALESCE(c.last_read_message_id, 0)").
+		GroupBy("a.post_id", "a.asker_id")
+
+	postSql, postArgs, err := postCounts.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", postSql, postArgs, err)
+	}
+
+	sqlStmt, sqlArgs, err := orderCounts.
+		Suffix("UNION ALL "+postSql, postArgs...).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	counts = []UnreadCount{}
+	if err := pgxscan.ScanAll(&counts, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	MODERATION_DISMISS_REPORTS     = "dismiss_reports"
+	MODERATION_HIDE_POST           = "hide_post"
+	MODERATION_UNHIDE_POST         = "unhide_post"
+	MODERATION_WARN_USER           = "warn_user"
+	MODERATION_SUSPEND_USER        = "suspend_user"
+	MODERATION_UNSUSPEND_USER      = "unsuspend_user"
+	MODERATION_UPDATE_MESSAGE_FLAG = "update_message_flag"
+	MODERATION_UPDATE_USER_ROLE    = "update_user_role"
+)
+
+const (
+	MODERATION_TARGET_POST    = "post"
+	MODERATION_TARGET_USER    = "user"
+	MODERATION_TARGET_MESSAGE = "message"
+)
+
+type ModerationLog struct {
+	Id         int        `json:"id" db:"id"`
+	ActorId    string     `json:"actorId" db:"actor_id"`
+	Action     string     `json:"action" db:"action"`
+	TargetType string     `json:"targetType" db:"target_type"`
+	TargetId   string     `json:"targetId" db:"target_id"`
+	Note       *string    `json:"note" db:"note"`
+	CreatedAt  *time.Time `json:"createdAt" db:"created_at"`
+}
+
+type CreateModerationLogPayload struct {
+	ActorId    string
+	Action     string
+	TargetType string
+	TargetId   string
+	Note       *string
+}
+
+func (r *Repository) GetModerationLogs(ctx context.Context, params url.Values) (logs []ModerationLog, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"id",
+		"actor_id",
+		"action",
+		"target_type",
+		"target_id",
+		"note",
+		"created_at",
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		From("moderation_log")
+
+	if targetType := params.Get("targetType"); targetType != "" {
+		psql = psql.Where(sq.Eq{"target_type": targetType})
+	}
+
+	if targetId := params.Get("targetId"); targetId != "" {
+		psql = psql.Where(sq.Eq{"target_id": targetId})
+	}
+
+	offset, limit := getPagination(params)
+
+	sqlStmt, sqlArgs, err := psql.OrderBy("created_at DESC", "id DESC").
+		Offset(offset).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&logs, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return logs, nil
+}
+
+func (r *Repository) CreateModerationLog(ctx context.Context, payload CreateModerationLogPayload) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"actor_id",
+		"action",
+		"target_type",
+		"target_id",
+		"note",
+	}
+
+	vals := []interface{}{
+		payload.ActorId,
+		payload.Action,
+		payload.TargetType,
+		payload.TargetId,
+		payload.Note,
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("moderation_log").
+		Columns(cols...).
+		Values(vals...).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+type Notification struct {
+	Id        int        `json:"id" db:"id"`
+	UserId    string     `json:"userId" db:"user_id"`
+	Type      string     `json:"type" db:"type"`
+	Title     string     `json:"title" db:"title"`
+	Body      string     `json:"body" db:"body"`
+	Target    *string    `json:"target" db:"target"`
+	ReadAt    *time.Time `json:"readAt" db:"read_at"`
+	CreatedAt *time.Time `json:"createdAt" db:"created_at"`
+}
+
+type CreateNotificationPayload struct {
+	UserId string
+	Type   string
+	Title  string
+	Body   string
+	Target *string
+}
+
+func (r *Repository) CreateNotification(ctx context.Context, payload CreateNotificationPayload) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"user_id",
+		"type",
+		"title",
+		"body",
+		"target",
+	}
+
+	vals := []interface{}{
+		payload.UserId,
+		payload.Type,
+		payload.Title,
+		payload.Body,
+		payload.Target,
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("notification").
+		Columns(cols...).
+		Values(vals...).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetNotifications(ctx context.Context, userId string, params url.Values) (notifications []Notification, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"id",
+		"user_id",
+		"type",
+		"title",
+		"body",
+		"target",
+		"read_at",
+		"created_at",
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		From("notification").
+		Where(sq.Eq{"user_id": userId})
+
+	if params.Get("unread") == "true" {
+		psql = psql.Where(sq.Eq{"read_at": nil})
+	}
+
+	offset, limit := getPagination(params)
+
+	sqlStmt, sqlArgs, err := psql.OrderBy("id DESC").
+		Offset(offset).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	notifications = []Notification{}
+	if err := pgxscan.ScanAll(&notifications, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *Repository) GetUnreadNotificationCount(ctx context.Context, userId string) (count int, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("count(*)").
+		From("notification").
+		Where(sq.Eq{"user_id": userId, "read_at": nil}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to execute: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return count, nil
+}
+
+func (r *Repository) MarkNotificationsRead(ctx context.Context, userId string, id *int) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update("notification").
+		Set("read_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"user_id": userId, "read_at": nil})
+
+	if id != nil {
+		psql = psql.Where(sq.Eq{"id": id})
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
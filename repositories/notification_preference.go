@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+type NotificationPreference struct {
+	UserId         string `json:"userId" db:"user_id"`
+	Locale         string `json:"locale" db:"locale"`
+	EmailOrders    bool   `json:"emailOrders" db:"email_orders"`
+	EmailMessages  bool   `json:"emailMessages" db:"email_messages"`
+	EmailReminders bool   `json:"emailReminders" db:"email_reminders"`
+	EmailDigests   bool   `json:"emailDigests" db:"email_digests"`
+	PushEnabled    bool   `json:"pushEnabled" db:"push_enabled"`
+}
+
+type UpdateNotificationPreferencePayload struct {
+	UserId         string
+	Locale         *string `json:"locale"`
+	EmailOrders    *bool   `json:"emailOrders"`
+	EmailMessages  *bool   `json:"emailMessages"`
+	EmailReminders *bool   `json:"emailReminders"`
+	EmailDigests   *bool   `json:"emailDigests"`
+	PushEnabled    *bool   `json:"pushEnabled"`
+}
+
+func (r *Repository) GetNotificationPreference(ctx context.Context, userId string) (preference *NotificationPreference, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"user_id",
+		"locale",
+		"email_orders",
+		"email_messages",
+		"email_reminders",
+		"email_digests",
+		"push_enabled",
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	sqlStmt, sqlArgs, err := psql.Select(cols...).
+		From("notification_preference").
+		Where(sq.Eq{"user_id": userId}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	var p NotificationPreference
+	if err := pgxscan.ScanOne(&p, rows); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return &NotificationPreference{
+				UserId:         userId,
+				Locale:         "ja",
+				EmailOrders:    true,
+				EmailMessages:  true,
+				EmailReminders: true,
+				EmailDigests:   true,
+				PushEnabled:    true,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return &p, nil
+}
+
+func (r *Repository) UpdateNotificationPreference(ctx context.Context, payload UpdateNotificationPreferencePayload) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{"user_id"}
+	vals := []interface{}{payload.UserId}
+	sets := []string{"updated_at = NOW()"}
+
+	if payload.Locale != nil {
+		cols, vals = append(cols, "locale"), append(vals, payload.Locale)
+		sets = append(sets, "locale = EXCLUDED.locale")
+	}
+	if payload.EmailOrders != nil {
+		cols, vals = append(cols, "email_orders"), append(vals, payload.EmailOrders)
+		sets = append(sets, "email_orders = EXCLUDED.email_orders")
+	}
+	if payload.EmailMessages != nil {
+		cols, vals = append(cols, "email_messages"), append(vals, payload.EmailMessages)
+		sets = append(sets, "email_messages = EXCLUDED.email_messages")
+	}
+	if payload.EmailReminders != nil {
+		cols, vals = append(cols, "email_reminders"), append(vals, payload.EmailReminders)
+		sets = append(sets, "email_reminders = EXCLUDED.email_reminders")
+	}
+	if payload.EmailDigests != nil {
+		cols, vals = append(cols, "email_digests"), append(vals, payload.EmailDigests)
+		sets = append(sets, "email_digests = EXCLUDED.email_digests")
+	}
+	if payload.PushEnabled != nil {
+		cols, vals = append(cols, "push_enabled"), append(vals, payload.PushEnabled)
+		sets = append(sets, "push_enabled = EXCLUDED.push_enabled")
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("notification_preference").
+		Columns(cols...).
+		Values(vals...).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET " + strings.Join(sets, ", ")).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
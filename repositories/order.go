@@ -8,22 +8,30 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/pgxscan"
 	"github.com/jackc/pgx/v4"
+	"github.com/katakeda/boardhop-api-service-go/utils"
+)
+
+var (
+	ACTIVE_ORDER_STATUSES = []string{"pending", "accepted"}
 )
 
 type Order struct {
-	Id        string     `json:"id" db:"id"`
-	PostId    string     `json:"postId" db:"post_id"`
-	UserId    string     `json:"userId" db:"user_id"`
-	PaymentId string     `json:"paymentId" db:"payment_id"`
-	Status    string     `json:"status" db:"status"`
-	Quantity  int8       `json:"quantity" db:"quantity"`
-	Total     float32    `json:"total" db:"total"`
-	StartDate *time.Time `json:"startDate" db:"start_date"`
-	EndDate   *time.Time `json:"endDate" db:"end_date"`
-	CreatedAt *time.Time `json:"createdAt" db:"created_at"`
-	DeletedAt *time.Time `json:"deletedAt" db:"deleted_at"`
-	Post      Post       `json:"post"`
-	Messages  []Message  `json:"messages"`
+	Id          string     `json:"id" db:"id"`
+	PostId      string     `json:"postId" db:"post_id"`
+	UserId      string     `json:"userId" db:"user_id"`
+	PaymentId   string     `json:"paymentId" db:"payment_id"`
+	Status      string     `json:"status" db:"status"`
+	Quantity    int8       `json:"quantity" db:"quantity"`
+	Total       float32    `json:"total" db:"total"`
+	StartDate   *time.Time `json:"startDate" db:"start_date"`
+	EndDate     *time.Time `json:"endDate" db:"end_date"`
+	CompletedAt *time.Time `json:"completedAt" db:"completed_at"`
+	CreatedAt   *time.Time `json:"createdAt" db:"created_at"`
+	DeletedAt   *time.Time `json:"deletedAt" db:"deleted_at"`
+	Post        Post       `json:"post"`
+	Messages    []Message  `json:"messages"`
+
+	UnreadCount *int `json:"unreadCount,omitempty" db:"unread_count"`
 }
 
 type CreateOrderPayload struct {
@@ -38,8 +46,14 @@ type CreateOrderPayload struct {
 	EndDate   *string `json:"endDate"`
 }
 
+type UpdateOrderPayload struct {
+	Status string `json:"status" binding:"required"`
+}
+
 type GetOrdersFilter struct {
-	UserId *string
+	UserId  *string
+	OwnerId *string
+	PostId  *string
 }
 
 func (r *Repository) GetOrders(ctx context.Context, filter GetOrdersFilter) (orders []Order, err error) {
@@ -54,8 +68,13 @@ func (r *Repository) GetOrders(ctx context.Context, filter GetOrdersFilter) (ord
 		}()
 	}
 
-	if filter.UserId == nil {
-		return nil, fmt.Errorf("userId cant be empty")
+	viewerId := filter.UserId
+	if viewerId == nil {
+		viewerId = filter.OwnerId
+	}
+
+	if viewerId == nil {
+		return nil, fmt.Errorf("userId or ownerId cant be empty")
 	}
 
 	cols := []string{
@@ -66,13 +85,34 @@ func (r *Repository) GetOrders(ctx context.Context, filter GetOrdersFilter) (ord
 		"status",
 		"quantity",
 		"total",
+		"start_date",
+		"end_date",
+		"completed_at",
 		"created_at",
 	}
 
+	unreadCount := sq.Expr(`(
+		SELECT count(*) FROM message a
+		LEFT JOIN message_read b ON b.order_id = a.order_id AND b.user_id = ?
+		WHERE a.order_id = "order".id AND a.user_id != ? AND a.id > COALESCE(b.last_read_message_id, 0)
+	) AS unread_count`, viewerId, viewerId)
+
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
 		Select(cols...).
-		From(`"order"`).
-		Where(sq.Eq{"user_id": filter.UserId})
+		Column(unreadCount).
+		From(`"order"`)
+
+	if filter.UserId != nil {
+		psql = psql.Where(sq.Eq{"user_id": filter.UserId})
+	}
+
+	if filter.OwnerId != nil {
+		psql = psql.Where("post_id IN (SELECT id FROM post WHERE user_id = ?)", filter.OwnerId)
+	}
+
+	if filter.PostId != nil {
+		psql = psql.Where(sq.Eq{"post_id": filter.PostId})
+	}
 
 	sqlStmt, sqlArgs, err := psql.ToSql()
 	if err != nil {
@@ -92,6 +132,10 @@ func (r *Repository) GetOrders(ctx context.Context, filter GetOrdersFilter) (ord
 }
 
 func (r *Repository) GetOrder(ctx context.Context, id string) (order *Order, err error) {
+	if !utils.IsUUID(id) {
+		return nil, nil
+	}
+
 	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
 	if !ok || tx == nil {
 		tx, _ = r.db.Begin(ctx)
@@ -111,6 +155,9 @@ func (r *Repository) GetOrder(ctx context.Context, id string) (order *Order, err
 		"status",
 		"quantity",
 		"total",
+		"start_date",
+		"end_date",
+		"completed_at",
 		"created_at",
 	}
 
@@ -133,8 +180,14 @@ func (r *Repository) GetOrder(ctx context.Context, id string) (order *Order, err
 		&order.Status,
 		&order.Quantity,
 		&order.Total,
+		&order.StartDate,
+		&order.EndDate,
+		&order.CompletedAt,
 		&order.CreatedAt,
 	); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("failed to execute: %s args: %v | %w", sqlStmt, sqlArgs, err)
 	}
 
@@ -188,20 +241,156 @@ func (r *Repository) CreateOrder(ctx context.Context, payload CreateOrderPayload
 	sqlStmt, sqlArgs, err := psql.Insert(`"order"`).
 		Columns(cols...).
 		Values(vals...).
-		Suffix("RETURNING id").
+		Suffix("RETURNING id, post_id, user_id, payment_id, status, quantity, total, start_date, end_date, created_at").
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
 	}
 
 	var newOrder Order
-	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&newOrder.Id); err != nil {
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(
+		&newOrder.Id,
+		&newOrder.PostId,
+		&newOrder.UserId,
+		&newOrder.PaymentId,
+		&newOrder.Status,
+		&newOrder.Quantity,
+		&newOrder.Total,
+		&newOrder.StartDate,
+		&newOrder.EndDate,
+		&newOrder.CreatedAt,
+	); err != nil {
 		return nil, fmt.Errorf("failed to execute: %s args: %v | %w", sqlStmt, sqlArgs, err)
 	}
 
 	return &newOrder, nil
 }
 
+func (r *Repository) UpdateOrderStatus(ctx context.Context, id string, from string, to string) (updated bool, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update(`"order"`).
+		Set("status", to).
+		Where(sq.Eq{"id": id, "status": from}).
+		Suffix("RETURNING id")
+
+	if to == "complete" {
+		psql = psql.Set("completed_at", sq.Expr("NOW()"))
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	var updatedId string
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&updatedId); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return true, nil
+}
+
+func (r *Repository) GetPickupReminderOrders(ctx context.Context, before time.Time) (orders []Order, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"id",
+		"post_id",
+		"user_id",
+		"payment_id",
+		"status",
+		"quantity",
+		"total",
+		"start_date",
+		"end_date",
+		"completed_at",
+		"created_at",
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		From(`"order"`).
+		Where(sq.Eq{"status": "accepted", "reminder_sent_at": nil}).
+		Where("start_date BETWEEN NOW() AND ?", before).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&orders, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	for idx := range orders {
+		if err := r.setOrderPost(ctx, &orders[idx]); err != nil {
+			return nil, fmt.Errorf("failed to set order post | %w", err)
+		}
+	}
+
+	return orders, nil
+}
+
+func (r *Repository) MarkOrderReminded(ctx context.Context, id string) (claimed bool, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update(`"order"`).
+		Set("reminder_sent_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id, "reminder_sent_at": nil}).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	var claimedId string
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&claimedId); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return true, nil
+}
+
 func (r *Repository) setOrderPost(ctx context.Context, order *Order) (err error) {
 	post, err := r.GetPost(ctx, order.PostId)
 	if err != nil {
@@ -223,3 +412,34 @@ func (r *Repository) setOrderMessages(ctx context.Context, order *Order) (err er
 
 	return nil
 }
+
+func (r *Repository) GetActiveOrderCount(ctx context.Context, userId string) (count int, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("COUNT(*)").
+		From(`"order" a`).
+		Join("post b ON a.post_id = b.id").
+		Where(sq.Or{sq.Eq{"a.user_id": userId}, sq.Eq{"b.user_id": userId}}).
+		Where(sq.Eq{"a.status": ACTIVE_ORDER_STATUSES}).
+		Where("a.deleted_at IS NULL").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return count, nil
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/url"
-	"strconv"
-	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -13,21 +11,28 @@ import (
 	"github.com/jackc/pgx/v4"
 )
 
-const (
-	DEFAULT_LIMIT = 50
-)
-
 var (
-	CATEGORY_PATH = map[string]string{
-		"all":       "root",
-		"surfboard": "root.1",
-		"snowboard": "root.2",
-		"eps":       "root.1.3",
-		"pu":        "root.1.3",
-		"wideboard": "root.2.4",
-	}
+	POST_RATING_AVERAGE = `(
+		SELECT AVG(r.post_rating)::float8 FROM review r
+		WHERE r.post_id = a.id AND r.post_rating IS NOT NULL AND ` + REVIEW_VISIBLE + `
+	) AS rating_average`
+	POST_RATING_COUNT = `(
+		SELECT COUNT(r.post_rating) FROM review r
+		WHERE r.post_id = a.id AND ` + REVIEW_VISIBLE + `
+	) AS rating_count`
+	POST_FAVORITE_COUNT = `(SELECT COUNT(*) FROM favorite fv WHERE fv.post_id = a.id) AS favorite_count`
 )
 
+func postCategories(ctx context.Context) string {
+	return `COALESCE((
+		SELECT json_agg(json_build_object(
+			'id', pcd.id, 'parentId', pcd.parent_id, 'path', pcd.path::text, 'value', pcd.value, 'label', ` + localizedLabel(ctx, "pcd") + `
+		) ORDER BY nlevel(pcd.path), pcd.id)
+		FROM post_category pc JOIN category pcd ON pc.category_id = pcd.id
+		WHERE pc.post_id = a.id
+	), '[]') AS categories`
+}
+
 type Post struct {
 	Id              string     `json:"id" db:"id"`
 	UserId          string     `json:"userId" db:"user_id"`
@@ -39,14 +44,24 @@ type Post struct {
 	PickupLongitude *float64   `json:"pickupLongitude" db:"pickup_longitude"`
 	CreatedAt       *time.Time `json:"createdAt" db:"created_at"`
 	DeletedAt       *time.Time `db:"deleted_at"`
+	HiddenAt        *time.Time `json:"hiddenAt,omitempty" db:"hidden_at"`
+	UserSuspendedAt *time.Time `json:"-" db:"user_suspended_at"`
 
-	Email      *string     `json:"email" db:"email"`
+	Email      *string     `json:"email,omitempty" db:"-"`
+	Phone      *string     `json:"phone,omitempty" db:"-"`
 	AvatarUrl  *string     `json:"avatarUrl" db:"avatar_url"`
 	FirstName  *string     `json:"firstName" db:"first_name"`
 	LastName   *string     `json:"lastName" db:"last_name"`
-	Categories *string     `json:"categories" db:"categories"`
+	Categories []Category  `json:"categories" db:"categories"`
 	Tags       []Tag       `json:"tags" db:"tags"`
 	Medias     []PostMedia `json:"medias" db:"medias"`
+
+	Specs map[string]interface{} `json:"specs" db:"specs"`
+
+	RatingAverage *float64 `json:"ratingAverage" db:"rating_average"`
+	RatingCount   int      `json:"ratingCount" db:"rating_count"`
+	FavoriteCount int      `json:"favoriteCount" db:"favorite_count"`
+	IsFavorited   *bool    `json:"isFavorited,omitempty" db:"-"`
 }
 
 type PostMedia struct {
@@ -70,6 +85,8 @@ type CreatePost struct {
 	Description     *string  `json:"description"`
 	PickupLatitude  *float64 `json:"pickupLatitude"`
 	PickupLongitude *float64 `json:"pickupLongitude"`
+
+	Specs map[string]interface{} `json:"specs"`
 }
 
 type UpdatePostPayload struct {
@@ -84,6 +101,8 @@ type UpdatePost struct {
 	Description     *string  `json:"description"`
 	PickupLatitude  *float64 `json:"pickupLatitude"`
 	PickupLongitude *float64 `json:"pickupLongitude"`
+
+	Specs map[string]interface{} `json:"specs"`
 }
 
 type PostRelationships struct {
@@ -114,6 +133,18 @@ type UpdatePostMedia struct {
 }
 
 func (r *Repository) GetPosts(ctx context.Context, params url.Values) (posts []Post, err error) {
+	return r.getPosts(ctx, params, nil, nil)
+}
+
+func (r *Repository) GetFavoritePosts(ctx context.Context, userId string, params url.Values) (posts []Post, err error) {
+	return r.getPosts(ctx, params, &userId, nil)
+}
+
+func (r *Repository) GetUserPosts(ctx context.Context, userId string, params url.Values) (posts []Post, err error) {
+	return r.getPosts(ctx, params, nil, &userId)
+}
+
+func (r *Repository) getPosts(ctx context.Context, params url.Values, favoritedBy *string, ownerId *string) (posts []Post, err error) {
 	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
 	if !ok || tx == nil {
 		tx, _ = r.db.Begin(ctx)
@@ -125,6 +156,35 @@ func (r *Repository) GetPosts(ctx context.Context, params url.Values) (posts []P
 		}()
 	}
 
+	psql, err := postsQuery(ctx, params, favoritedBy, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&posts, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	for idx := range posts {
+		if err := r.setPostMedias(ctx, &posts[idx]); err != nil {
+			return nil, fmt.Errorf("failed to set post medias | %w", err)
+		}
+	}
+
+	return posts, nil
+}
+
+func postsQuery(ctx context.Context, params url.Values, favoritedBy *string, ownerId *string) (sq.SelectBuilder, error) {
 	cols := []string{
 		"a.id",
 		"a.user_id",
@@ -134,74 +194,59 @@ func (r *Repository) GetPosts(ctx context.Context, params url.Values) (posts []P
 		"a.pickup_latitude",
 		"a.pickup_longitude",
 		"a.created_at",
-		"b.email",
+		"a.specs",
+		"a.hidden_at",
 		"b.avatar_url",
-		`string_agg(DISTINCT d. "value", ',') AS categories`,
+		postCategories(ctx),
+		POST_RATING_AVERAGE,
+		POST_RATING_COUNT,
+		POST_FAVORITE_COUNT,
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
 		Select(cols...).From("post a").
 		Join(`"user" b ON a.user_id = b.id`).
-		Join("post_category c ON a.id = c.post_id").
-		Join("category d ON c.category_id = d.id").
+		LeftJoin("post_category c ON a.id = c.post_id").
+		LeftJoin("category d ON c.category_id = d.id").
 		LeftJoin("post_tag e ON a.id = e.post_id").
-		LeftJoin("tag f ON e.tag_id = f.id")
-
-	if categories := params.Get("cats"); categories != "" {
-		category := strings.Split(categories, ",")[0]
-		if path, exists := CATEGORY_PATH[category]; exists {
-			psql = psql.Where("d.path <@ ?", path)
-		}
-	}
-
-	if tags := params.Get("tags"); tags != "" {
-		psql = psql.Where(sq.Eq{"f.value": strings.Split(tags, ",")})
-	}
-
-	if userId := params.Get("uid"); userId != "" {
-		psql = psql.Where(sq.Eq{"a.user_id": userId})
-	}
-
-	offset := 0
-	if p := params.Get("p"); p != "" {
-		offset, err = strconv.Atoi(p)
-		if err != nil {
-			offset = 0
-		}
-	}
+		LeftJoin("tag f ON e.tag_id = f.id").
+		Where("a.deleted_at IS NULL")
 
-	limit := DEFAULT_LIMIT
-	if l := params.Get("l"); l != "" {
-		limit, err = strconv.Atoi(l)
-		if err != nil {
-			limit = DEFAULT_LIMIT
-		}
+	if ownerId != nil {
+		psql = psql.Where(sq.Eq{"a.user_id": ownerId})
+	} else {
+		psql = psql.Where("a.hidden_at IS NULL").
+			Where("b.suspended_at IS NULL")
 	}
 
-	sqlStmt, sqlArgs, err := psql.Offset(uint64(offset)*uint64(limit)).
-		Limit(uint64(limit)).
-		GroupBy("a.id", "b.id").
-		ToSql()
+	filter, err := ParsePostsFilter(params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+		return psql, fmt.Errorf("failed to parse filter | %w", err)
 	}
 
-	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
-	}
+	psql = filter.apply(psql)
 
-	if err := pgxscan.ScanAll(&posts, rows); err != nil {
-		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	groupBy := []string{"a.id", "b.id"}
+
+	if favoritedBy != nil {
+		psql = psql.Join("favorite g ON a.id = g.post_id AND g.user_id = ?", favoritedBy)
+		groupBy = append(groupBy, "g.created_at")
 	}
 
-	for idx := range posts {
-		if err := r.setPostMedias(ctx, &posts[idx]); err != nil {
-			return nil, fmt.Errorf("failed to set post medias | %w", err)
+	switch filter.Sort {
+	case "popular":
+		psql = psql.OrderBy("favorite_count DESC", "a.created_at DESC")
+	case "newest":
+		psql = psql.OrderBy("a.created_at DESC")
+	default:
+		if favoritedBy != nil {
+			psql = psql.OrderBy("g.created_at DESC")
 		}
 	}
 
-	return posts, nil
+	offset, limit := getPagination(params)
+
+	return psql.Offset(offset).Limit(limit).GroupBy(groupBy...), nil
 }
 
 func (r *Repository) GetPost(ctx context.Context, id string) (post *Post, err error) {
@@ -226,11 +271,17 @@ func (r *Repository) GetPost(ctx context.Context, id string) (post *Post, err er
 		"a.pickup_latitude",
 		"a.pickup_longitude",
 		"a.created_at",
-		"b.email",
+		"a.specs",
+		"a.deleted_at",
+		"a.hidden_at",
+		"b.suspended_at AS user_suspended_at",
 		"b.avatar_url",
 		"b.first_name",
 		"b.last_name",
-		`string_agg(DISTINCT d. "value", ',') AS categories`,
+		postCategories(ctx),
+		POST_RATING_AVERAGE,
+		POST_RATING_COUNT,
+		POST_FAVORITE_COUNT,
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
@@ -238,8 +289,6 @@ func (r *Repository) GetPost(ctx context.Context, id string) (post *Post, err er
 	sqlStmt, sqlArgs, err := psql.Select(cols...).
 		From("post a").
 		Join(`"user" b ON a.user_id = b.id`).
-		Join("post_category c ON a.id = c.post_id").
-		Join("category d ON c.category_id = d.id").
 		Where(sq.Eq{"a.id": id}).
 		GroupBy("a.id", "b.id").
 		ToSql()
@@ -283,6 +332,11 @@ func (r *Repository) CreatePost(ctx context.Context, payload CreatePost) (post *
 		}()
 	}
 
+	specs := payload.Specs
+	if specs == nil {
+		specs = map[string]interface{}{}
+	}
+
 	cols := []string{
 		"user_id",
 		"title",
@@ -291,6 +345,7 @@ func (r *Repository) CreatePost(ctx context.Context, payload CreatePost) (post *
 		"description",
 		"pickup_latitude",
 		"pickup_longitude",
+		"specs",
 	}
 
 	vals := []interface{}{
@@ -301,6 +356,7 @@ func (r *Repository) CreatePost(ctx context.Context, payload CreatePost) (post *
 		payload.Description,
 		payload.PickupLatitude,
 		payload.PickupLongitude,
+		specs,
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
@@ -356,6 +412,9 @@ func (r *Repository) UpdatePost(ctx context.Context, id string, payload UpdatePo
 	if payload.PickupLongitude != nil {
 		psql = psql.Set("pickup_longitude", payload.PickupLongitude)
 	}
+	if payload.Specs != nil {
+		psql = psql.Set("specs", payload.Specs)
+	}
 
 	sqlStmt, sqlArgs, err := psql.Suffix("RETURNING id").ToSql()
 	if err != nil {
@@ -630,15 +689,19 @@ func (r *Repository) setPostTags(ctx context.Context, post *Post) (err error) {
 
 	cols := []string{
 		"b.id",
-		"b.type",
+		"b.type_id",
+		tagTypeName("c") + " AS type",
+		"c.value AS type_value",
+		localizedLabel(ctx, "c") + " AS type_label",
 		"b.value",
-		"b.label",
+		localizedLabel(ctx, "b") + " AS label",
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 	sqlStmt, sqlArgs, err := psql.Select(cols...).
 		From("post_tag a").
 		Join(`"tag" b ON a.tag_id = b.id`).
+		Join("tag_type c ON b.type_id = c.id").
 		Where(sq.Eq{"a.post_id": post.Id}).
 		ToSql()
 	if err != nil {
@@ -659,3 +722,65 @@ func (r *Repository) setPostTags(ctx context.Context, post *Post) (err error) {
 
 	return nil
 }
+
+func (r *Repository) DeletePostsByUserId(ctx context.Context, userId string) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update("post").
+		Set("deleted_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"user_id": userId}).
+		Where("deleted_at IS NULL").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) SetPostHidden(ctx context.Context, id string, hidden bool) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	var hiddenAt interface{}
+	if hidden {
+		hiddenAt = sq.Expr("NOW()")
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update("post").
+		Set("hidden_at", hiddenAt).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
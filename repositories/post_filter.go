@@ -0,0 +1,233 @@
+package repositories
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	MAX_SEARCH_RADIUS = 500
+)
+
+var (
+	POST_FILTER_KEYS   = []string{"cats", "tags", "uid", "priceMin", "priceMax", "lat", "lng", "radius"}
+	SPEC_RANGE_PATTERN = regexp.MustCompile(`^([a-z][a-zA-Z0-9]{0,49})(Min|Max)$`)
+	POST_SORTS         = map[string]bool{
+		"":        true,
+		"newest":  true,
+		"popular": true,
+	}
+)
+
+type PostsFilter struct {
+	Category     *string
+	Tags         []string
+	UserId       *string
+	PriceMin     *float64
+	PriceMax     *float64
+	Latitude     *float64
+	Longitude    *float64
+	Radius       *float64
+	CreatedAfter *time.Time
+	SpecRanges   []SpecRange
+	Sort         string
+}
+
+type SpecRange struct {
+	Key string
+	Min *float64
+	Max *float64
+}
+
+func PostsCategory(params url.Values) string {
+	category := strings.Split(params.Get("cats"), ",")[0]
+	if category == "all" {
+		return ""
+	}
+
+	return category
+}
+
+func SpecRangeKey(param string) (string, bool) {
+	match := SPEC_RANGE_PATTERN.FindStringSubmatch(param)
+	if match == nil || match[1] == "price" {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+func ParsePostsFilter(params url.Values) (filter PostsFilter, err error) {
+	if category := PostsCategory(params); category != "" {
+		filter.Category = &category
+	}
+
+	if tags := params.Get("tags"); tags != "" {
+		filter.Tags = strings.Split(tags, ",")
+	}
+
+	if userId := params.Get("uid"); userId != "" {
+		filter.UserId = &userId
+	}
+
+	for key, dest := range map[string]**float64{
+		"priceMin": &filter.PriceMin,
+		"priceMax": &filter.PriceMax,
+		"lat":      &filter.Latitude,
+		"lng":      &filter.Longitude,
+		"radius":   &filter.Radius,
+	} {
+		if *dest, err = parseFloatParam(params, key); err != nil {
+			return filter, err
+		}
+	}
+
+	if filter.PriceMin != nil && *filter.PriceMin < 0 || filter.PriceMax != nil && *filter.PriceMax < 0 {
+		return filter, fmt.Errorf("price range must not be negative")
+	}
+
+	if filter.PriceMin != nil && filter.PriceMax != nil && *filter.PriceMin > *filter.PriceMax {
+		return filter, fmt.Errorf("priceMin must not be greater than priceMax")
+	}
+
+	if (filter.Latitude == nil) != (filter.Longitude == nil) || (filter.Latitude == nil) != (filter.Radius == nil) {
+		return filter, fmt.Errorf("lat, lng and radius must be given together")
+	}
+
+	if filter.Latitude != nil {
+		if *filter.Latitude < -90 || *filter.Latitude > 90 || *filter.Longitude < -180 || *filter.Longitude > 180 {
+			return filter, fmt.Errorf("lat or lng is out of range")
+		}
+		if *filter.Radius <= 0 || *filter.Radius > MAX_SEARCH_RADIUS {
+			return filter, fmt.Errorf("radius must be between 0 and %d km", MAX_SEARCH_RADIUS)
+		}
+	}
+
+	if since := params.Get("since"); since != "" {
+		createdAfter, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return filter, fmt.Errorf("since must be an RFC3339 timestamp")
+		}
+		filter.CreatedAfter = &createdAfter
+	}
+
+	if filter.SpecRanges, err = parseSpecRanges(params); err != nil {
+		return filter, err
+	}
+
+	filter.Sort = params.Get("sort")
+	if !POST_SORTS[filter.Sort] {
+		return filter, fmt.Errorf("unknown sort: %s", filter.Sort)
+	}
+
+	return filter, nil
+}
+
+func (f PostsFilter) apply(psql sq.SelectBuilder) sq.SelectBuilder {
+	if f.Category != nil {
+		psql = psql.Where(`(d.path || d.id::text) <@ (
+			SELECT path || id::text FROM category WHERE value = ? AND retired_at IS NULL
+		)`, *f.Category)
+	}
+
+	if len(f.Tags) > 0 {
+		psql = psql.Where(sq.Eq{"f.value": f.Tags})
+	}
+
+	if f.UserId != nil {
+		psql = psql.Where(sq.Eq{"a.user_id": f.UserId})
+	}
+
+	if f.PriceMin != nil {
+		psql = psql.Where(sq.GtOrEq{"a.price": f.PriceMin})
+	}
+
+	if f.PriceMax != nil {
+		psql = psql.Where(sq.LtOrEq{"a.price": f.PriceMax})
+	}
+
+	if f.Latitude != nil {
+		psql = psql.Where(`6371 * acos(LEAST(1,
+			cos(radians(?)) * cos(radians(a.pickup_latitude)) * cos(radians(a.pickup_longitude) - radians(?))
+			+ sin(radians(?)) * sin(radians(a.pickup_latitude))
+		)) <= ?`, f.Latitude, f.Longitude, f.Latitude, f.Radius)
+	}
+
+	if f.CreatedAfter != nil {
+		psql = psql.Where(sq.Gt{"a.created_at": f.CreatedAfter})
+	}
+
+	for _, spec := range f.SpecRanges {
+		value := "CASE WHEN jsonb_typeof(a.specs->?) = 'number' THEN (a.specs->>?)::float8 END"
+		if spec.Min != nil {
+			psql = psql.Where(value+" >= ?", spec.Key, spec.Key, spec.Min)
+		}
+		if spec.Max != nil {
+			psql = psql.Where(value+" <= ?", spec.Key, spec.Key, spec.Max)
+		}
+	}
+
+	return psql
+}
+
+func parseSpecRanges(params url.Values) ([]SpecRange, error) {
+	ranges := map[string]*SpecRange{}
+	for param := range params {
+		key, ok := SpecRangeKey(param)
+		if !ok {
+			continue
+		}
+
+		value, err := parseFloatParam(params, param)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+
+		if ranges[key] == nil {
+			ranges[key] = &SpecRange{Key: key}
+		}
+		if strings.HasSuffix(param, "Min") {
+			ranges[key].Min = value
+		} else {
+			ranges[key].Max = value
+		}
+	}
+
+	specs := []SpecRange{}
+	for _, spec := range ranges {
+		if spec.Min != nil && spec.Max != nil && *spec.Min > *spec.Max {
+			return nil, fmt.Errorf("%sMin must not be greater than %sMax", spec.Key, spec.Key)
+		}
+		specs = append(specs, *spec)
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Key < specs[j].Key
+	})
+
+	return specs, nil
+}
+
+func parseFloatParam(params url.Values, key string) (*float64, error) {
+	value := params.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", key)
+	}
+
+	return &v, nil
+}
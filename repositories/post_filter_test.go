@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePostsFilterSpecRanges(t *testing.T) {
+	params, _ := url.ParseQuery("volumeMin=28&volumeMax=32&lengthInMin=60&priceMin=100")
+	filter, err := ParsePostsFilter(params)
+	assert.Nil(t, err)
+	assert.Len(t, filter.SpecRanges, 2)
+	assert.Equal(t, "lengthIn", filter.SpecRanges[0].Key)
+	assert.Nil(t, filter.SpecRanges[0].Max)
+	assert.Equal(t, "volume", filter.SpecRanges[1].Key)
+	assert.Equal(t, 28.0, *filter.SpecRanges[1].Min)
+	assert.Equal(t, 32.0, *filter.SpecRanges[1].Max)
+
+	params, _ = url.ParseQuery("volumeMin=32&volumeMax=28")
+	_, err = ParsePostsFilter(params)
+	assert.EqualError(t, err, "volumeMin must not be greater than volumeMax")
+
+	params, _ = url.ParseQuery("volumeMin=big")
+	_, err = ParsePostsFilter(params)
+	assert.EqualError(t, err, "volumeMin must be a number")
+}
@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	REPORT_REASONS = map[string]bool{
+		"spam":            true,
+		"prohibited_item": true,
+		"misleading":      true,
+		"offensive":       true,
+		"scam":            true,
+		"other":           true,
+	}
+
+	REPORT_STATUSES = map[string]bool{
+		"pending":   true,
+		"dismissed": true,
+		"actioned":  true,
+	}
+
+	REPORT_ACTIONS = map[string]bool{
+		"dismiss": true,
+		"hide":    true,
+		"warn":    true,
+		"suspend": true,
+	}
+)
+
+type PostReport struct {
+	Id         int        `json:"id" db:"id"`
+	PostId     string     `json:"postId" db:"post_id"`
+	ReporterId string     `json:"reporterId" db:"reporter_id"`
+	Reason     string     `json:"reason" db:"reason"`
+	Details    *string    `json:"details" db:"details"`
+	Status     string     `json:"status" db:"status"`
+	CreatedAt  *time.Time `json:"createdAt" db:"created_at"`
+	ResolvedAt *time.Time `json:"resolvedAt" db:"resolved_at"`
+
+	PostTitle   *string `json:"postTitle,omitempty" db:"post_title"`
+	PostUserId  *string `json:"postUserId,omitempty" db:"post_user_id"`
+	ReportCount *int    `json:"reportCount,omitempty" db:"report_count"`
+}
+
+type CreatePostReportPayload struct {
+	PostId     string
+	ReporterId string
+	Reason     string  `json:"reason" binding:"required"`
+	Details    *string `json:"details"`
+}
+
+type ReportActionPayload struct {
+	Action string  `json:"action" binding:"required"`
+	Note   *string `json:"note"`
+}
+
+type UpdatePostVisibilityPayload struct {
+	Hidden *bool   `json:"hidden" binding:"required"`
+	Note   *string `json:"note"`
+}
+
+type UpdateUserSuspensionPayload struct {
+	Suspended *bool   `json:"suspended" binding:"required"`
+	Note      *string `json:"note"`
+}
+
+func (r *Repository) GetPostReports(ctx context.Context, params url.Values) (reports []PostReport, err error) {
+	return r.getPostReports(ctx, nil, nil, params)
+}
+
+func (r *Repository) GetPostReportsByReporter(ctx context.Context, reporterId string) (reports []PostReport, err error) {
+	return r.getPostReports(ctx, nil, &reporterId, nil)
+}
+
+func (r *Repository) GetPostReport(ctx context.Context, id int) (report *PostReport, err error) {
+	reports, err := r.getPostReports(ctx, &id, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reports) <= 0 {
+		return nil, nil
+	}
+
+	return &reports[0], nil
+}
+
+func (r *Repository) getPostReports(ctx context.Context, id *int, reporterId *string, params url.Values) (reports []PostReport, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"a.id",
+		"a.post_id",
+		"a.reporter_id",
+		"a.reason",
+		"a.details",
+		"a.status",
+		"a.created_at",
+		"a.resolved_at",
+		"b.title AS post_title",
+		"b.user_id AS post_user_id",
+		"(SELECT COUNT(*) FROM post_report c WHERE c.post_id = a.post_id AND c.status = a.status) AS report_count",
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		From("post_report a").
+		Join("post b ON a.post_id = b.id")
+
+	if id != nil {
+		psql = psql.Where(sq.Eq{"a.id": id})
+	} else if reporterId != nil {
+		psql = psql.Where(sq.Eq{"a.reporter_id": reporterId}).
+			OrderBy("a.created_at ASC", "a.id ASC")
+	} else {
+		status := params.Get("status")
+		if status == "" {
+			status = "pending"
+		}
+
+		offset, limit := getPagination(params)
+		psql = psql.Where(sq.Eq{"a.status": status}).
+			OrderBy("a.created_at ASC", "a.id ASC").
+			Offset(offset).
+			Limit(limit)
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&reports, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return reports, nil
+}
+
+func (r *Repository) CreatePostReport(ctx context.Context, payload CreatePostReportPayload) (report *PostReport, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"post_id",
+		"reporter_id",
+		"reason",
+		"details",
+	}
+
+	vals := []interface{}{
+		payload.PostId,
+		payload.ReporterId,
+		payload.Reason,
+		payload.Details,
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("post_report").
+		Columns(cols...).
+		Values(vals...).
+		Suffix("ON CONFLICT (post_id, reporter_id) DO NOTHING RETURNING id, post_id, reporter_id, reason, details, status, created_at, resolved_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	var p PostReport
+	if err := pgxscan.ScanOne(&p, rows); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return &p, nil
+}
+
+func (r *Repository) ResolvePostReports(ctx context.Context, postId string, status string) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update("post_report").
+		Set("status", status).
+		Set("resolved_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"post_id": postId, "status": "pending"}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostsQueryPopularSort(t *testing.T) {
+	params, _ := url.ParseQuery("sort=popular")
+	psql, err := postsQuery(context.Background(), params, nil, nil)
+	assert.Nil(t, err)
+
+	sqlStmt, _, err := psql.ToSql()
+	assert.Nil(t, err)
+	assert.Contains(t, sqlStmt, POST_FAVORITE_COUNT)
+	assert.Contains(t, sqlStmt, "ORDER BY favorite_count DESC, a.created_at DESC")
+}
+
+func TestPostsQueryFavoritedBy(t *testing.T) {
+	userId := "renter"
+	psql, err := postsQuery(context.Background(), url.Values{}, &userId, nil)
+	assert.Nil(t, err)
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	assert.Nil(t, err)
+	assert.Contains(t, sqlStmt, "JOIN favorite g ON a.id = g.post_id AND g.user_id = $1")
+	assert.Contains(t, sqlStmt, "ORDER BY g.created_at DESC")
+	assert.Equal(t, []interface{}{&userId}, sqlArgs)
+}
+
+func TestPostsQueryListsUncategorizedPosts(t *testing.T) {
+	psql, err := postsQuery(context.Background(), url.Values{}, nil, nil)
+	assert.Nil(t, err)
+
+	sqlStmt, _, err := psql.ToSql()
+	assert.Nil(t, err)
+	assert.Contains(t, sqlStmt, "LEFT JOIN post_category c ON a.id = c.post_id")
+	assert.Contains(t, sqlStmt, "LEFT JOIN category d ON c.category_id = d.id")
+	assert.NotContains(t, sqlStmt, "(d.path || d.id::text) <@")
+	assert.Contains(t, sqlStmt, "), '[]') AS categories")
+
+	// Posts without post_category rows scan the COALESCE fallback.
+	var post Post
+	assert.Nil(t, json.Unmarshal([]byte(`{"id":"post-1","categories":[]}`), &post))
+
+	body, err := json.Marshal(post)
+	assert.Nil(t, err)
+	assert.Contains(t, string(body), `"categories":[]`)
+}
+
+func TestPostsQueryCategoryFilter(t *testing.T) {
+	params, _ := url.ParseQuery("cats=surfboard")
+	psql, err := postsQuery(context.Background(), params, nil, nil)
+	assert.Nil(t, err)
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	assert.Nil(t, err)
+	assert.Contains(t, sqlStmt, "(d.path || d.id::text) <@")
+	assert.Contains(t, sqlArgs, "surfboard")
+}
@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	REVIEW_WINDOW_DAYS = 14
+	REVIEW_WINDOW      = REVIEW_WINDOW_DAYS * 24 * time.Hour
+)
+
+var (
+	REVIEW_VISIBLE = fmt.Sprintf(`(
+		EXISTS (SELECT 1 FROM review rv WHERE rv.order_id = r.order_id AND rv.reviewer_id <> r.reviewer_id)
+		OR (SELECT ro.completed_at FROM "order" ro WHERE ro.id = r.order_id) < NOW() - INTERVAL '%d days'
+	)`, REVIEW_WINDOW_DAYS)
+)
+
+type Review struct {
+	Id         int        `json:"id" db:"id"`
+	OrderId    string     `json:"orderId" db:"order_id"`
+	PostId     string     `json:"postId" db:"post_id"`
+	ReviewerId string     `json:"reviewerId" db:"reviewer_id"`
+	RevieweeId string     `json:"revieweeId" db:"reviewee_id"`
+	Role       string     `json:"role" db:"role"`
+	Rating     int        `json:"rating" db:"rating"`
+	Comment    *string    `json:"comment" db:"comment"`
+	PostRating *int       `json:"postRating" db:"post_rating"`
+	CreatedAt  *time.Time `json:"createdAt" db:"created_at"`
+
+	ReviewerName      string  `json:"reviewerName" db:"reviewer_name"`
+	ReviewerAvatarUrl *string `json:"reviewerAvatarUrl" db:"reviewer_avatar_url"`
+	Visible           bool    `json:"visible" db:"visible"`
+}
+
+type GetReviewsFilter struct {
+	OrderId     *string
+	PostId      *string
+	UserId      *string
+	Role        *string
+	VisibleOnly bool
+}
+
+type CreateReviewPayload struct {
+	OrderId    string
+	PostId     string
+	ReviewerId string
+	RevieweeId string
+	Role       string
+	Rating     int     `json:"rating" binding:"required"`
+	Comment    *string `json:"comment"`
+	PostRating *int    `json:"postRating"`
+}
+
+func (r *Repository) GetReviews(ctx context.Context, filter GetReviewsFilter, params url.Values) (reviews []Review, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"r.id",
+		"r.order_id",
+		"r.post_id",
+		"r.reviewer_id",
+		"r.reviewee_id",
+		"r.role",
+		"r.rating",
+		"r.comment",
+		"r.post_rating",
+		"r.created_at",
+		"u.first_name || ' ' || LEFT(u.last_name, 1) || '.' AS reviewer_name",
+		"u.avatar_url AS reviewer_avatar_url",
+		REVIEW_VISIBLE + " AS visible",
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		From("review r").
+		Join(`"user" u ON r.reviewer_id = u.id`)
+
+	if filter.OrderId != nil {
+		psql = psql.Where(sq.Eq{"r.order_id": filter.OrderId})
+	}
+
+	if filter.PostId != nil {
+		psql = psql.Where(sq.Eq{"r.post_id": filter.PostId})
+	}
+
+	if filter.UserId != nil {
+		psql = psql.Where(sq.Or{
+			sq.Eq{"r.reviewer_id": filter.UserId},
+			sq.And{sq.Eq{"r.reviewee_id": filter.UserId}, sq.Expr(REVIEW_VISIBLE)},
+		})
+	}
+
+	if filter.OrderId == nil && filter.PostId == nil && filter.UserId == nil {
+		return nil, fmt.Errorf("orderId, postId or userId is required")
+	}
+
+	if filter.Role != nil {
+		psql = psql.Where(sq.Eq{"r.role": filter.Role})
+	}
+
+	if filter.VisibleOnly {
+		psql = psql.Where(REVIEW_VISIBLE)
+	}
+
+	psql = psql.OrderBy("r.created_at DESC", "r.id DESC")
+
+	if params != nil {
+		offset, limit := getPagination(params)
+		psql = psql.Offset(offset).Limit(limit)
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&reviews, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (r *Repository) CreateReview(ctx context.Context, payload CreateReviewPayload) (review *Review, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"order_id",
+		"post_id",
+		"reviewer_id",
+		"reviewee_id",
+		"role",
+		"rating",
+		"comment",
+		"post_rating",
+	}
+
+	vals := []interface{}{
+		payload.OrderId,
+		payload.PostId,
+		payload.ReviewerId,
+		payload.RevieweeId,
+		payload.Role,
+		payload.Rating,
+		payload.Comment,
+		payload.PostRating,
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("review").
+		Columns(cols...).
+		Values(vals...).
+		Suffix("ON CONFLICT (order_id, reviewer_id) DO NOTHING RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	newReview := Review{
+		OrderId:    payload.OrderId,
+		PostId:     payload.PostId,
+		ReviewerId: payload.ReviewerId,
+		RevieweeId: payload.RevieweeId,
+		Role:       payload.Role,
+		Rating:     payload.Rating,
+		Comment:    payload.Comment,
+		PostRating: payload.PostRating,
+	}
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&newReview.Id, &newReview.CreatedAt); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to execute: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return &newReview, nil
+}
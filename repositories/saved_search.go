@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+type SavedSearch struct {
+	Id             int        `json:"id" db:"id"`
+	UserId         string     `json:"userId" db:"user_id"`
+	Name           string     `json:"name" db:"name"`
+	Query          string     `json:"query" db:"query"`
+	LastNotifiedAt *time.Time `json:"lastNotifiedAt" db:"last_notified_at"`
+	CreatedAt      *time.Time `json:"createdAt" db:"created_at"`
+}
+
+type CreateSavedSearchPayload struct {
+	UserId string
+	Name   string `json:"name" binding:"required"`
+	Query  string `json:"query" binding:"required"`
+}
+
+func (r *Repository) GetSavedSearches(ctx context.Context, userId string) (searches []SavedSearch, err error) {
+	return r.getSavedSearches(ctx, sq.Eq{"user_id": userId})
+}
+
+func (r *Repository) GetDueSavedSearches(ctx context.Context, before time.Time) (searches []SavedSearch, err error) {
+	return r.getSavedSearches(ctx, sq.And{
+		sq.Lt{"last_notified_at": before},
+		sq.Expr(`user_id IN (SELECT id FROM "user" WHERE deleted_at IS NULL)`),
+	})
+}
+
+func (r *Repository) getSavedSearches(ctx context.Context, where sq.Sqlizer) (searches []SavedSearch, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"id",
+		"user_id",
+		"name",
+		"query",
+		"last_notified_at",
+		"created_at",
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		From("saved_search").
+		Where(where).
+		OrderBy("created_at ASC", "id ASC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&searches, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return searches, nil
+}
+
+func (r *Repository) CreateSavedSearch(ctx context.Context, payload CreateSavedSearchPayload) (search *SavedSearch, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("saved_search").
+		Columns("user_id", "name", "query").
+		Values(payload.UserId, payload.Name, payload.Query).
+		Suffix("RETURNING id, user_id, name, query, last_notified_at, created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	var s SavedSearch
+	if err := pgxscan.ScanOne(&s, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return &s, nil
+}
+
+func (r *Repository) DeleteSavedSearch(ctx context.Context, userId string, id int) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Delete("saved_search").
+		Where(sq.Eq{"id": id, "user_id": userId}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkSavedSearchNotified(ctx context.Context, id int, last time.Time, at time.Time) (claimed bool, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update("saved_search").
+		Set("last_notified_at", at).
+		Where(sq.Eq{"id": id, "last_notified_at": last}).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	var claimedId int
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&claimedId); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return true, nil
+}
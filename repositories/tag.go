@@ -3,23 +3,71 @@ package repositories
 import (
 	"context"
 	"fmt"
-	"net/url"
-	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/pgxscan"
 	"github.com/jackc/pgx/v4"
-	"github.com/katakeda/boardhop-api-service-go/utils"
+)
+
+const (
+	TAG_TYPE_RELEVANT = `(
+		NOT EXISTS (SELECT 1 FROM tag_type_category x WHERE x.tag_type_id = b.id)
+		OR EXISTS (
+			SELECT 1 FROM tag_type_category x
+			JOIN category y ON x.category_id = y.id
+			JOIN category z ON (y.path || y.id::text) @> (z.path || z.id::text)
+			WHERE x.tag_type_id = b.id AND (z.id = ANY(?) OR z.value = ANY(?))
+		)
+	)`
 )
 
 type Tag struct {
-	Id    int    `json:"id" db:"id"`
-	Type  string `json:"type" db:"type"`
-	Value string `json:"value" db:"value"`
-	Label string `json:"label" db:"label"`
+	Id        int    `json:"id" db:"id"`
+	TypeId    int    `json:"typeId" db:"type_id"`
+	Type      string `json:"type" db:"type"`
+	TypeValue string `json:"typeValue" db:"type_value"`
+	TypeLabel string `json:"typeLabel" db:"type_label"`
+	Value     string `json:"value" db:"value"`
+	Label     string `json:"label" db:"label"`
+}
+
+type GetTagsFilter struct {
+	CategoryIds    []int
+	CategoryValues []string
+}
+
+type CreateTagPayload struct {
+	TypeId int               `json:"typeId" binding:"required"`
+	Value  string            `json:"value" binding:"required"`
+	Label  string            `json:"label" binding:"required"`
+	Labels map[string]string `json:"labels"`
+}
+
+type UpdateTagPayload struct {
+	TypeId *int              `json:"typeId"`
+	Value  *string           `json:"value"`
+	Label  *string           `json:"label"`
+	Labels map[string]string `json:"labels"`
+}
+
+func (r *Repository) GetTags(ctx context.Context, filter GetTagsFilter) (tags []Tag, err error) {
+	return r.getTags(ctx, nil, filter)
+}
+
+func (r *Repository) GetTag(ctx context.Context, id int) (tag *Tag, err error) {
+	tags, err := r.getTags(ctx, &id, GetTagsFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) <= 0 {
+		return nil, nil
+	}
+
+	return &tags[0], nil
 }
 
-func (r *Repository) GetTags(ctx context.Context, params url.Values) (tags []Tag, err error) {
+func (r *Repository) getTags(ctx context.Context, id *int, filter GetTagsFilter) (tags []Tag, err error) {
 	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
 	if !ok || tx == nil {
 		tx, _ = r.db.Begin(ctx)
@@ -32,28 +80,36 @@ func (r *Repository) GetTags(ctx context.Context, params url.Values) (tags []Tag
 	}
 
 	cols := []string{
-		"id",
-		"type",
-		"value",
-		"label",
+		"a.id",
+		"a.type_id",
+		tagTypeName("b") + " AS type",
+		"b.value AS type_value",
+		localizedLabel(ctx, "b") + " AS type_label",
+		"a.value",
+		localizedLabel(ctx, "a") + " AS label",
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
 		Select(cols...).
-		From("tag")
+		From("tag a").
+		Join("tag_type b ON a.type_id = b.id")
 
-	types := []string{"Skill Level"}
-	typeArr := strings.Split(params.Get("type"), ",")
-	typeMap := utils.StrArrayToMap(typeArr)
-	if _, exists := typeMap["surfboard"]; exists {
-		types = append(types, "Surfboard Brand")
+	if id != nil {
+		psql = psql.Where(sq.Eq{"a.id": id})
 	}
-	if _, exists := typeMap["snowboard"]; exists {
-		types = append(types, "Snowboard Brand")
+
+	if len(filter.CategoryIds) > 0 || len(filter.CategoryValues) > 0 {
+		categoryIds, categoryValues := filter.CategoryIds, filter.CategoryValues
+		if categoryIds == nil {
+			categoryIds = []int{}
+		}
+		if categoryValues == nil {
+			categoryValues = []string{}
+		}
+		psql = psql.Where(TAG_TYPE_RELEVANT, categoryIds, categoryValues)
 	}
-	psql = psql.Where(sq.Eq{"type": types})
 
-	sqlStmt, sqlArgs, err := psql.ToSql()
+	sqlStmt, sqlArgs, err := psql.OrderBy("b.id", "a.id").ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
 	}
@@ -69,3 +125,175 @@ func (r *Repository) GetTags(ctx context.Context, params url.Values) (tags []Tag
 
 	return tags, nil
 }
+
+func (r *Repository) TagExists(ctx context.Context, typeId int, value string, excludeId *int) (exists bool, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("COUNT(*) > 0").
+		From("tag").
+		Where(sq.Eq{"type_id": typeId, "value": value})
+
+	if excludeId != nil {
+		psql = psql.Where(sq.NotEq{"id": excludeId})
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return exists, nil
+}
+
+func (r *Repository) GetTagPostCount(ctx context.Context, id int) (count int, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("COUNT(*)").
+		From("post_tag").
+		Where(sq.Eq{"tag_id": id}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return count, nil
+}
+
+func (r *Repository) CreateTag(ctx context.Context, payload CreateTagPayload) (id int, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"type_id",
+		"value",
+		"label",
+		"labels",
+	}
+
+	vals := []interface{}{
+		payload.TypeId,
+		payload.Value,
+		payload.Label,
+		labelsOrEmpty(payload.Labels),
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("tag").
+		Columns(cols...).
+		Values(vals...).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) UpdateTag(ctx context.Context, id int, payload UpdateTagPayload) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update("tag").
+		Where(sq.Eq{"id": id})
+
+	if payload.TypeId != nil {
+		psql = psql.Set("type_id", payload.TypeId)
+	}
+	if payload.Value != nil {
+		psql = psql.Set("value", payload.Value)
+	}
+	if payload.Label != nil {
+		psql = psql.Set("label", payload.Label)
+	}
+	if payload.Labels != nil {
+		psql = psql.Set("labels", payload.Labels)
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteTag(ctx context.Context, id int) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Delete("tag").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
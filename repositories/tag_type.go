@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+)
+
+type TagType struct {
+	Id          int    `json:"id" db:"id"`
+	Value       string `json:"value" db:"value"`
+	Label       string `json:"label" db:"label"`
+	CategoryIds []int  `json:"categoryIds" db:"category_ids"`
+}
+
+type CreateTagTypePayload struct {
+	Value       string            `json:"value" binding:"required"`
+	Label       string            `json:"label" binding:"required"`
+	Labels      map[string]string `json:"labels"`
+	CategoryIds []int             `json:"categoryIds"`
+}
+
+type UpdateTagTypePayload struct {
+	Label       *string           `json:"label"`
+	Labels      map[string]string `json:"labels"`
+	CategoryIds *[]int            `json:"categoryIds"`
+}
+
+func (r *Repository) GetTagTypes(ctx context.Context) (tagTypes []TagType, err error) {
+	return r.getTagTypes(ctx, nil)
+}
+
+func (r *Repository) GetTagType(ctx context.Context, id int) (tagType *TagType, err error) {
+	tagTypes, err := r.getTagTypes(ctx, &id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tagTypes) <= 0 {
+		return nil, nil
+	}
+
+	return &tagTypes[0], nil
+}
+
+func (r *Repository) getTagTypes(ctx context.Context, id *int) (tagTypes []TagType, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"a.id",
+		"a.value",
+		localizedLabel(ctx, "a") + " AS label",
+		"COALESCE(array_agg(b.category_id ORDER BY b.category_id) FILTER (WHERE b.category_id IS NOT NULL), '{}') AS category_ids",
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(cols...).
+		From("tag_type a").
+		LeftJoin("tag_type_category b ON a.id = b.tag_type_id")
+
+	if id != nil {
+		psql = psql.Where(sq.Eq{"a.id": id})
+	}
+
+	sqlStmt, sqlArgs, err := psql.GroupBy("a.id").OrderBy("a.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if err := pgxscan.ScanAll(&tagTypes, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return tagTypes, nil
+}
+
+func (r *Repository) CreateTagType(ctx context.Context, payload CreateTagTypePayload) (id *int, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("tag_type").
+		Columns("value", "label", "labels").
+		Values(payload.Value, payload.Label, labelsOrEmpty(payload.Labels)).
+		Suffix("ON CONFLICT (value) DO NOTHING RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	var newId int
+	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&newId); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return &newId, nil
+}
+
+func (r *Repository) UpdateTagType(ctx context.Context, id int, payload UpdateTagTypePayload) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update("tag_type").
+		Where(sq.Eq{"id": id})
+
+	if payload.Label != nil {
+		psql = psql.Set("label", payload.Label)
+	}
+	if payload.Labels != nil {
+		psql = psql.Set("labels", payload.Labels)
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) SetTagTypeCategories(ctx context.Context, id int, categoryIds []int) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	sqlStmt, sqlArgs, err := psql.Delete("tag_type_category").
+		Where(sq.Eq{"tag_type_id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if len(categoryIds) <= 0 {
+		return nil
+	}
+
+	insert := psql.Insert("tag_type_category").Columns("tag_type_id", "category_id")
+	for idx := range categoryIds {
+		insert = insert.Values(id, categoryIds[idx])
+	}
+
+	sqlStmt, sqlArgs, err = insert.Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
@@ -3,32 +3,74 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/pgxscan"
 	"github.com/jackc/pgx/v4"
 )
 
+const (
+	ROLE_USER       = "user"
+	ROLE_SHOP_STAFF = "shop_staff"
+	ROLE_ADMIN      = "admin"
+)
+
+var (
+	USER_ROLES = map[string]bool{
+		ROLE_USER:       true,
+		ROLE_SHOP_STAFF: true,
+		ROLE_ADMIN:      true,
+	}
+)
+
 type User struct {
-	Id           string  `json:"id" db:"id"`
-	Email        string  `json:"email" db:"email"`
-	FirstName    string  `json:"firstName" db:"first_name"`
-	LastName     string  `json:"lastName" db:"last_name"`
-	Phone        *string `json:"phone" db:"phone"`
-	AvatarUrl    *string `json:"avatarUrl" db:"avatar_url"`
-	GoogleAuthId *string `json:"googleAuthId" db:"google_auth_id"`
+	Id           string     `json:"id" db:"id"`
+	Email        string     `json:"email" db:"email"`
+	FirstName    string     `json:"firstName" db:"first_name"`
+	LastName     string     `json:"lastName" db:"last_name"`
+	Phone        *string    `json:"phone" db:"phone"`
+	AvatarUrl    *string    `json:"avatarUrl" db:"avatar_url"`
+	GoogleAuthId *string    `json:"googleAuthId" db:"google_auth_id"`
+	Bio          *string    `json:"bio" db:"bio"`
+	Role         string     `json:"role" db:"role"`
+	CreatedAt    *time.Time `json:"createdAt" db:"created_at"`
+	SuspendedAt  *time.Time `json:"suspendedAt,omitempty" db:"suspended_at"`
 }
 
 type UserSignupPayload struct {
-	Email        string `json:"email"`
-	FirstName    string `json:"firstName"`
-	LastName     string `json:"lastName"`
-	GoogleAuthId string `json:"googleAuthId"`
+	Email        string `json:"-"`
+	FirstName    string `json:"firstName" binding:"required"`
+	LastName     string `json:"lastName" binding:"required"`
+	GoogleAuthId string `json:"-"`
+
+	EmailVerified bool `json:"-"`
+}
+
+type PublicUser struct {
+	Id            string     `json:"id" db:"id"`
+	DisplayName   string     `json:"displayName" db:"display_name"`
+	AvatarUrl     *string    `json:"avatarUrl" db:"avatar_url"`
+	Bio           *string    `json:"bio" db:"bio"`
+	MemberSince   *time.Time `json:"memberSince" db:"created_at"`
+	ReviewAverage *float64   `json:"reviewAverage" db:"review_average"`
+	ReviewCount   int        `json:"reviewCount" db:"review_count"`
+	ResponseRate  *float64   `json:"responseRate" db:"response_rate"`
+
+	ActiveListingCount int    `json:"activeListingCount" db:"active_listing_count"`
+	ActiveListings     []Post `json:"activeListings" db:"-"`
+}
+
+type UpdateUserRolePayload struct {
+	Role string `json:"role" binding:"required"`
 }
 
-type UserLoginPayload struct {
-	Email        string `json:"email"`
-	GoogleAuthId string `json:"googleAuthId"`
+type UpdateUserPayload struct {
+	FirstName *string `json:"firstName"`
+	LastName  *string `json:"lastName"`
+	Phone     *string `json:"phone"`
+	Bio       *string `json:"bio"`
+	AvatarUrl *string `json:"-"`
 }
 
 func (r *Repository) UserSignup(ctx context.Context, payload UserSignupPayload) (user *User, err error) {
@@ -61,7 +103,9 @@ func (r *Repository) UserSignup(ctx context.Context, payload UserSignupPayload)
 		Insert(`"user"`).
 		Columns(cols...).
 		Values(vals...).
-		Suffix("RETURNING id").
+		Suffix(`ON CONFLICT (email) DO UPDATE SET google_auth_id = EXCLUDED.google_auth_id
+			WHERE ("user".google_auth_id IS NULL AND ?::boolean) OR "user".google_auth_id = EXCLUDED.google_auth_id
+			RETURNING id`, payload.EmailVerified).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
@@ -69,6 +113,9 @@ func (r *Repository) UserSignup(ctx context.Context, payload UserSignupPayload)
 
 	var newUser User
 	if err := tx.QueryRow(ctx, sqlStmt, sqlArgs...).Scan(&newUser.Id); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("failed to execute: %s args: %v | %w", sqlStmt, sqlArgs, err)
 	}
 
@@ -76,19 +123,152 @@ func (r *Repository) UserSignup(ctx context.Context, payload UserSignupPayload)
 }
 
 func (r *Repository) GetUserByGoogleAuthId(ctx context.Context, googleAuthId interface{}) (*User, error) {
+	return r.getUser(ctx, sq.Eq{"google_auth_id": googleAuthId})
+}
+
+func (r *Repository) GetUserById(ctx context.Context, id string) (*User, error) {
+	return r.getUser(ctx, sq.Eq{"id": id})
+}
+
+func (r *Repository) GetPublicUser(ctx context.Context, id string) (user *PublicUser, err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	cols := []string{
+		"a.id",
+		"a.first_name || ' ' || LEFT(a.last_name, 1) || '.' AS display_name",
+		"a.avatar_url",
+		"a.bio",
+		"a.created_at",
+		`(
+			SELECT COUNT(*) FROM post p
+			WHERE p.user_id = a.id AND p.deleted_at IS NULL AND p.hidden_at IS NULL AND a.suspended_at IS NULL
+		) AS active_listing_count`,
+		`(
+			SELECT AVG(r.rating)::float8 FROM review r
+			WHERE r.reviewee_id = a.id AND ` + REVIEW_VISIBLE + `
+		) AS review_average`,
+		`(
+			SELECT COUNT(*) FROM review r
+			WHERE r.reviewee_id = a.id AND ` + REVIEW_VISIBLE + `
+		) AS review_count`,
+		`(
+			SELECT AVG(CASE WHEN c.replied THEN 1.0 ELSE 0.0 END)::float8
+			FROM (
+				SELECT bool_or(m.user_id = p.user_id) AS replied
+				FROM message m
+				LEFT JOIN "order" o ON m.order_id = o.id
+				JOIN post p ON p.id = COALESCE(m.post_id, o.post_id)
+				WHERE p.user_id = a.id
+				GROUP BY m.post_id, m.asker_id, m.order_id
+				HAVING bool_or(m.user_id <> p.user_id)
+			) c
+		) AS response_rate`,
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	sqlStmt, sqlArgs, err := psql.Select(cols...).
+		From(`"user" a`).
+		Where(sq.Eq{"a.id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	var u PublicUser
+	if err := pgxscan.ScanOne(&u, rows); err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to scan rows | %w", err)
+	}
+
+	return &u, nil
+}
+
+func (r *Repository) UpdateUser(ctx context.Context, id string, payload UpdateUserPayload) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update(`"user"`).
+		Where(sq.Eq{"id": id})
+
+	updated := false
+	if payload.FirstName != nil {
+		psql, updated = psql.Set("first_name", payload.FirstName), true
+	}
+	if payload.LastName != nil {
+		psql, updated = psql.Set("last_name", payload.LastName), true
+	}
+	if payload.Phone != nil {
+		psql, updated = psql.Set("phone", payload.Phone), true
+	}
+	if payload.Bio != nil {
+		psql, updated = psql.Set("bio", payload.Bio), true
+	}
+	if payload.AvatarUrl != nil {
+		psql, updated = psql.Set("avatar_url", payload.AvatarUrl), true
+	}
+
+	if !updated {
+		return nil
+	}
+
+	sqlStmt, sqlArgs, err := psql.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) getUser(ctx context.Context, where sq.Eq) (*User, error) {
 	cols := []string{
 		"id",
 		"email",
 		"first_name",
 		"last_name",
+		"phone",
+		"avatar_url",
 		"google_auth_id",
+		"bio",
+		"role",
+		"created_at",
+		"suspended_at",
 	}
 
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
 	sqlStmt, sqlArgs, err := psql.Select(cols...).
 		From(`"user"`).
-		Where(sq.Eq{"google_auth_id": googleAuthId}).
+		Where(where).
 		ToSql()
 
 	if err != nil {
@@ -99,9 +279,148 @@ func (r *Repository) GetUserByGoogleAuthId(ctx context.Context, googleAuthId int
 	{
 		err := pgxscan.Get(ctx, r.db, &user, sqlStmt, sqlArgs...)
 		if err != nil {
+			if err.Error() == pgx.ErrNoRows.Error() {
+				return nil, nil
+			}
 			return nil, fmt.Errorf("failed to execute: %s %w", sqlStmt, err)
 		}
 	}
 
 	return &user, nil
 }
+
+func (r *Repository) UpdateUserRole(ctx context.Context, id string, role string) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update(`"user"`).
+		Set("role", role).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) SetUserSuspended(ctx context.Context, id string, suspended bool) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	var suspendedAt interface{}
+	if suspended {
+		suspendedAt = sq.Expr("NOW()")
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Update(`"user"`).
+		Set("suspended_at", suspendedAt).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) LockUsers(ctx context.Context, ids []string) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		return fmt.Errorf("row locks require a db txn")
+	}
+
+	sqlStmt, sqlArgs, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("id").
+		From(`"user"`).
+		Where(sq.Eq{"id": ids}).
+		OrderBy("id").
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	rows, err := tx.Query(ctx, sqlStmt, sqlArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+	rows.Close()
+
+	return rows.Err()
+}
+
+func (r *Repository) AnonymizeUser(ctx context.Context, id string) (err error) {
+	tx, ok := ctx.Value(TxnKey).(pgx.Tx)
+	if !ok || tx == nil {
+		tx, _ = r.db.Begin(ctx)
+		defer func() error {
+			if err != nil {
+				return tx.Rollback(ctx)
+			}
+			return tx.Commit(ctx)
+		}()
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	sqlStmt, sqlArgs, err := psql.Update(`"user"`).
+		Set("email", sq.Expr("'deleted+' || id || '@boardhop.invalid'")).
+		Set("first_name", "Deleted").
+		Set("last_name", "User").
+		Set("phone", nil).
+		Set("avatar_url", nil).
+		Set("bio", nil).
+		Set("google_auth_id", nil).
+		Set("deleted_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+		return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+	}
+
+	for _, table := range []string{"device_token", "notification_preference", "favorite", "saved_search"} {
+		sqlStmt, sqlArgs, err = psql.Delete(table).
+			Where(sq.Eq{"user_id": id}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+		}
+
+		if _, err = tx.Exec(ctx, sqlStmt, sqlArgs...); err != nil {
+			return fmt.Errorf("failed to execute query: %s args: %v | %w", sqlStmt, sqlArgs, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+const (
+	EXPORT_PAGE_SIZE = 500
+)
+
+type userExport struct {
+	Profile                *repositories.User                   `json:"profile"`
+	NotificationPreference *repositories.NotificationPreference `json:"notificationPreference"`
+	Posts                  []repositories.Post                  `json:"posts"`
+	Favorites              []repositories.Post                  `json:"favorites"`
+	Orders                 []repositories.Order                 `json:"orders"`
+	ReceivedOrders         []repositories.Order                 `json:"receivedOrders"`
+	Messages               []repositories.Message               `json:"messages"`
+	Reviews                []repositories.Review                `json:"reviews"`
+	Reports                []repositories.PostReport            `json:"reports"`
+	ExportedAt             time.Time                            `json:"exportedAt"`
+}
+
+func (s *Service) ExportUser(c *gin.Context) {
+	s.exportUser(c)
+}
+
+func (s *Service) DeleteUser(c *gin.Context) {
+	s.deleteUser(c)
+}
+
+func (s *Service) exportUser(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to export user |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while exporting user data")
+		}
+	}()
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "zip" {
+		c.JSON(http.StatusBadRequest, "Format must be json or zip")
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	export := userExport{Profile: user, ExportedAt: time.Now().UTC()}
+
+	if export.NotificationPreference, err = s.repo.GetNotificationPreference(c, user.Id); err != nil {
+		return fmt.Errorf("failed to get notification preference | %w", err)
+	}
+
+	for page := 0; ; page++ {
+		params := url.Values{
+			"p": {strconv.Itoa(page)},
+			"l": {strconv.Itoa(EXPORT_PAGE_SIZE)},
+		}
+
+		posts, err := s.repo.GetUserPosts(c, user.Id, params)
+		if err != nil {
+			return fmt.Errorf("failed to get posts | %w", err)
+		}
+
+		export.Posts = append(export.Posts, posts...)
+		if len(posts) < EXPORT_PAGE_SIZE {
+			break
+		}
+	}
+
+	for page := 0; ; page++ {
+		params := url.Values{
+			"p": {strconv.Itoa(page)},
+			"l": {strconv.Itoa(EXPORT_PAGE_SIZE)},
+		}
+
+		favorites, err := s.repo.GetFavoritePosts(c, user.Id, params)
+		if err != nil {
+			return fmt.Errorf("failed to get favorites | %w", err)
+		}
+
+		export.Favorites = append(export.Favorites, favorites...)
+		if len(favorites) < EXPORT_PAGE_SIZE {
+			break
+		}
+	}
+
+	if export.Orders, err = s.repo.GetOrders(c, repositories.GetOrdersFilter{UserId: &user.Id}); err != nil {
+		return fmt.Errorf("failed to get orders | %w", err)
+	}
+
+	if export.ReceivedOrders, err = s.repo.GetOrders(c, repositories.GetOrdersFilter{OwnerId: &user.Id}); err != nil {
+		return fmt.Errorf("failed to get received orders | %w", err)
+	}
+
+	if export.Messages, err = s.repo.GetMessages(c, repositories.GetMessagesFilter{UserId: &user.Id}, nil); err != nil {
+		return fmt.Errorf("failed to get messages | %w", err)
+	}
+
+	if export.Reviews, err = s.repo.GetReviews(c, repositories.GetReviewsFilter{UserId: &user.Id}, nil); err != nil {
+		return fmt.Errorf("failed to get reviews | %w", err)
+	}
+
+	if export.Reports, err = s.repo.GetPostReportsByReporter(c, user.Id); err != nil {
+		return fmt.Errorf("failed to get reports | %w", err)
+	}
+
+	filename := fmt.Sprintf("boardhop-export-%s.%s", export.ExportedAt.Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	if format == "json" {
+		c.JSON(http.StatusOK, export)
+		return nil
+	}
+
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "application/zip")
+
+	zw := zip.NewWriter(c.Writer)
+	for _, file := range []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"notification_preference.json", export.NotificationPreference},
+		{"posts.json", export.Posts},
+		{"favorites.json", export.Favorites},
+		{"orders.json", export.Orders},
+		{"received_orders.json", export.ReceivedOrders},
+		{"messages.json", export.Messages},
+		{"reviews.json", export.Reviews},
+		{"reports.json", export.Reports},
+	} {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			log.Println("Failed to create export file |", file.name, err)
+			return nil
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			log.Println("Failed to write export file |", file.name, err)
+			return nil
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		log.Println("Failed to close export archive |", err)
+	}
+
+	return nil
+}
+
+func (s *Service) deleteUser(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to delete user |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while deleting user")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	// createOrder takes the same lock, so no rental can start between this check and the delete.
+	if err := s.repo.LockUsers(ctx, []string{user.Id}); err != nil {
+		return fmt.Errorf("failed to lock user | %w", err)
+	}
+
+	count, err := s.repo.GetActiveOrderCount(ctx, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get active order count | %w", err)
+	}
+
+	if count > 0 {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusConflict, "Cannot delete account while rentals are pending or in progress")
+		return nil
+	}
+
+	if err := s.repo.DeletePostsByUserId(ctx, user.Id); err != nil {
+		return fmt.Errorf("failed to delete posts | %w", err)
+	}
+
+	if err := s.repo.AnonymizeUser(ctx, user.Id); err != nil {
+		return fmt.Errorf("failed to anonymize user | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
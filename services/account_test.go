@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportUser(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	hiddenAt := time.Now()
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "owner-uid").
+		Return(&repositories.User{Id: "owner"}, nil)
+	mockRepo.
+		On("GetNotificationPreference", mock.Anything, "owner").
+		Return(&repositories.NotificationPreference{}, nil)
+	mockRepo.
+		On("GetUserPosts", mock.Anything, "owner", mock.Anything).
+		Return([]repositories.Post{{Id: "hidden", HiddenAt: &hiddenAt}}, nil)
+	mockRepo.
+		On("GetFavoritePosts", mock.Anything, "owner", mock.Anything).
+		Return([]repositories.Post{{Id: "favorite"}}, nil)
+	mockRepo.
+		On("GetOrders", mock.Anything, repositories.GetOrdersFilter{UserId: strPtr("owner")}).
+		Return([]repositories.Order{{Id: "placed"}}, nil)
+	mockRepo.
+		On("GetOrders", mock.Anything, repositories.GetOrdersFilter{OwnerId: strPtr("owner")}).
+		Return([]repositories.Order{{Id: "received"}}, nil)
+	mockRepo.
+		On("GetMessages", mock.Anything, mock.Anything, mock.Anything).
+		Return([]repositories.Message{}, nil)
+	mockRepo.
+		On("GetReviews", mock.Anything, repositories.GetReviewsFilter{UserId: strPtr("owner")}, mock.Anything).
+		Return([]repositories.Review{{Id: 1}}, nil)
+	mockRepo.
+		On("GetPostReportsByReporter", mock.Anything, "owner").
+		Return([]repositories.PostReport{{Id: 2}}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.GET("/user/export", func(c *gin.Context) { c.Set("googleAuthId", "owner-uid") }, svc.ExportUser)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user/export", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var export struct {
+		Posts          []repositories.Post       `json:"posts"`
+		Favorites      []repositories.Post       `json:"favorites"`
+		Orders         []repositories.Order      `json:"orders"`
+		ReceivedOrders []repositories.Order      `json:"receivedOrders"`
+		Reviews        []repositories.Review     `json:"reviews"`
+		Reports        []repositories.PostReport `json:"reports"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &export))
+	assert.Equal(t, "hidden", export.Posts[0].Id)
+	assert.NotNil(t, export.Posts[0].HiddenAt)
+	assert.Equal(t, "favorite", export.Favorites[0].Id)
+	assert.Equal(t, "placed", export.Orders[0].Id)
+	assert.Equal(t, "received", export.ReceivedOrders[0].Id)
+	assert.Len(t, export.Reviews, 1)
+	assert.Len(t, export.Reports, 1)
+}
+
+func TestDeleteUserBlockedByActiveRentals(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.On("BeginTxn", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("RollbackTxn", mock.Anything).Return(nil)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "renter-uid").
+		Return(&repositories.User{Id: "renter"}, nil)
+	mockRepo.
+		On("LockUsers", mock.Anything, []string{"renter"}).
+		Return(nil)
+	mockRepo.
+		On("GetActiveOrderCount", mock.Anything, "renter").
+		Return(1, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.DELETE("/user", func(c *gin.Context) { c.Set("googleAuthId", "renter-uid") }, svc.DeleteUser)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/user", nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockRepo.AssertNotCalled(t, "AnonymizeUser", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "DeletePostsByUserId", mock.Anything, mock.Anything)
+}
+
+func TestDeleteUser(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.On("BeginTxn", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("CommitTxn", mock.Anything).Return(nil)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "owner-uid").
+		Return(&repositories.User{Id: "owner"}, nil)
+	mockRepo.
+		On("LockUsers", mock.Anything, []string{"owner"}).
+		Return(nil)
+	mockRepo.
+		On("GetActiveOrderCount", mock.Anything, "owner").
+		Return(0, nil)
+	mockRepo.
+		On("DeletePostsByUserId", mock.Anything, "owner").
+		Return(nil)
+	mockRepo.
+		On("AnonymizeUser", mock.Anything, "owner").
+		Return(nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.DELETE("/user", func(c *gin.Context) { c.Set("googleAuthId", "owner-uid") }, svc.DeleteUser)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/user", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockRepo.AssertCalled(t, "DeletePostsByUserId", mock.Anything, "owner")
+	mockRepo.AssertCalled(t, "AnonymizeUser", mock.Anything, "owner")
+	mockRepo.AssertCalled(t, "CommitTxn", mock.Anything)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,511 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mailer"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+func (s *Service) GetMessageFlags(c *gin.Context) {
+	s.getMessageFlags(c)
+}
+
+func (s *Service) UpdateMessageFlag(c *gin.Context) {
+	s.updateMessageFlag(c)
+}
+
+func (s *Service) UpdateUserRole(c *gin.Context) {
+	s.updateUserRole(c)
+}
+
+func (s *Service) GetPostReports(c *gin.Context) {
+	s.getPostReports(c)
+}
+
+func (s *Service) ResolvePostReport(c *gin.Context) {
+	s.resolvePostReport(c)
+}
+
+func (s *Service) UpdatePostVisibility(c *gin.Context) {
+	s.updatePostVisibility(c)
+}
+
+func (s *Service) UpdateUserSuspension(c *gin.Context) {
+	s.updateUserSuspension(c)
+}
+
+func (s *Service) GetModerationLogs(c *gin.Context) {
+	s.getModerationLogs(c)
+}
+
+func (s *Service) getMessageFlags(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get message flags |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting message flags")
+		}
+	}()
+
+	params := c.Request.URL.Query()
+	if status := params.Get("status"); status != "" && !repositories.FLAG_STATUSES[status] {
+		c.JSON(http.StatusBadRequest, "Invalid status")
+		return nil
+	}
+
+	flags, err := s.repo.GetMessageFlags(c, params)
+	if err != nil {
+		return fmt.Errorf("failed to get message flags | %w", err)
+	}
+
+	if flags == nil {
+		flags = []repositories.MessageFlag{}
+	}
+
+	c.JSON(http.StatusOK, flags)
+
+	return nil
+}
+
+func (s *Service) updateMessageFlag(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update message flag |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating message flag")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Invalid message flag id")
+		return nil
+	}
+
+	payload := repositories.UpdateMessageFlagPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil || !repositories.FLAG_STATUSES[payload.Status] {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Status must be one of pending, dismissed or removed")
+		return nil
+	}
+
+	admin, err := s.getUser(c)
+	if err != nil || admin == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	flag, err := s.repo.UpdateMessageFlagStatus(ctx, id, payload.Status)
+	if err != nil {
+		return fmt.Errorf("failed to update message flag | %w", err)
+	}
+
+	if flag == nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusNotFound, "Message flag not found")
+		return nil
+	}
+
+	if flag.Status == "removed" {
+		if err := s.repo.RemoveMessage(ctx, flag.MessageId); err != nil {
+			return fmt.Errorf("failed to remove message | %w", err)
+		}
+	}
+
+	if err := s.repo.CreateModerationLog(ctx, repositories.CreateModerationLogPayload{
+		ActorId:    admin.Id,
+		Action:     repositories.MODERATION_UPDATE_MESSAGE_FLAG,
+		TargetType: repositories.MODERATION_TARGET_MESSAGE,
+		TargetId:   strconv.Itoa(flag.MessageId),
+		Note:       &flag.Status,
+	}); err != nil {
+		return fmt.Errorf("failed to create moderation log | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	c.JSON(http.StatusOK, flag)
+
+	return nil
+}
+
+func (s *Service) updateUserRole(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update user role |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating user role")
+		}
+	}()
+
+	payload := repositories.UpdateUserRolePayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil || !repositories.USER_ROLES[payload.Role] {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Role must be one of user, shop_staff or admin")
+		return nil
+	}
+
+	admin, err := s.getUser(c)
+	if err != nil || admin == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	id := c.Param("id")
+	if id == admin.Id {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Cannot change your own role")
+		return nil
+	}
+
+	user, err := s.repo.GetUserById(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get user | %w", err)
+	}
+
+	if user == nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusNotFound, "User not found")
+		return nil
+	}
+
+	if err := s.repo.UpdateUserRole(ctx, id, payload.Role); err != nil {
+		return fmt.Errorf("failed to update user role | %w", err)
+	}
+
+	if err := s.repo.CreateModerationLog(ctx, repositories.CreateModerationLogPayload{
+		ActorId:    admin.Id,
+		Action:     repositories.MODERATION_UPDATE_USER_ROLE,
+		TargetType: repositories.MODERATION_TARGET_USER,
+		TargetId:   id,
+		Note:       &payload.Role,
+	}); err != nil {
+		return fmt.Errorf("failed to create moderation log | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	user.Role = payload.Role
+
+	c.JSON(http.StatusOK, user)
+
+	return nil
+}
+
+func (s *Service) getPostReports(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get post reports |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting post reports")
+		}
+	}()
+
+	params := c.Request.URL.Query()
+	if status := params.Get("status"); status != "" && !repositories.REPORT_STATUSES[status] {
+		c.JSON(http.StatusBadRequest, "Invalid status")
+		return nil
+	}
+
+	reports, err := s.repo.GetPostReports(c, params)
+	if err != nil {
+		return fmt.Errorf("failed to get post reports | %w", err)
+	}
+
+	if reports == nil {
+		reports = []repositories.PostReport{}
+	}
+
+	c.JSON(http.StatusOK, reports)
+
+	return nil
+}
+
+func (s *Service) resolvePostReport(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to resolve post report |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while resolving post report")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Invalid report id")
+		return nil
+	}
+
+	payload := repositories.ReportActionPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil || !repositories.REPORT_ACTIONS[payload.Action] {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Action must be one of dismiss, hide, warn or suspend")
+		return nil
+	}
+
+	admin, err := s.getUser(c)
+	if err != nil || admin == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	report, err := s.repo.GetPostReport(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get post report | %w", err)
+	}
+
+	if report == nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusNotFound, "Report not found")
+		return nil
+	}
+
+	if report.Status != "pending" {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusConflict, "Report has already been resolved")
+		return nil
+	}
+
+	ownerId := ""
+	if report.PostUserId != nil {
+		ownerId = *report.PostUserId
+	}
+
+	if payload.Action == "suspend" && ownerId == admin.Id {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Cannot suspend your own account")
+		return nil
+	}
+
+	status := "actioned"
+	logPayload := repositories.CreateModerationLogPayload{
+		ActorId: admin.Id,
+		Note:    payload.Note,
+	}
+
+	switch payload.Action {
+	case "dismiss":
+		status = "dismissed"
+		logPayload.Action = repositories.MODERATION_DISMISS_REPORTS
+		logPayload.TargetType = repositories.MODERATION_TARGET_POST
+		logPayload.TargetId = report.PostId
+	case "hide":
+		if err := s.repo.SetPostHidden(ctx, report.PostId, true); err != nil {
+			return fmt.Errorf("failed to hide post | %w", err)
+		}
+		logPayload.Action = repositories.MODERATION_HIDE_POST
+		logPayload.TargetType = repositories.MODERATION_TARGET_POST
+		logPayload.TargetId = report.PostId
+	case "warn":
+		logPayload.Action = repositories.MODERATION_WARN_USER
+		logPayload.TargetType = repositories.MODERATION_TARGET_USER
+		logPayload.TargetId = ownerId
+	case "suspend":
+		if err := s.repo.SetUserSuspended(ctx, ownerId, true); err != nil {
+			return fmt.Errorf("failed to suspend user | %w", err)
+		}
+		logPayload.Action = repositories.MODERATION_SUSPEND_USER
+		logPayload.TargetType = repositories.MODERATION_TARGET_USER
+		logPayload.TargetId = ownerId
+	}
+
+	if err := s.repo.ResolvePostReports(ctx, report.PostId, status); err != nil {
+		return fmt.Errorf("failed to resolve post reports | %w", err)
+	}
+
+	if err := s.repo.CreateModerationLog(ctx, logPayload); err != nil {
+		return fmt.Errorf("failed to create moderation log | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	if payload.Action == "warn" {
+		post := repositories.Post{Id: report.PostId, UserId: ownerId}
+		if report.PostTitle != nil {
+			post.Title = *report.PostTitle
+		}
+		s.notify(notification{
+			UserId:   ownerId,
+			Template: mailer.TEMPLATE_MODERATION_WARNING,
+			Post:     &post,
+			Message:  payload.Note,
+			Path:     "/posts/" + report.PostId,
+		})
+	}
+
+	report, err = s.repo.GetPostReport(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get post report | %w", err)
+	}
+
+	c.JSON(http.StatusOK, report)
+
+	return nil
+}
+
+func (s *Service) updatePostVisibility(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update post visibility |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating post visibility")
+		}
+	}()
+
+	payload := repositories.UpdatePostVisibilityPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Hidden is required")
+		return nil
+	}
+
+	admin, err := s.getUser(c)
+	if err != nil || admin == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	post, err := s.repo.GetPost(ctx, c.Param("id"))
+	if err != nil {
+		return fmt.Errorf("failed to get post | %w", err)
+	}
+
+	if post == nil || post.DeletedAt != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusNotFound, "Post not found")
+		return nil
+	}
+
+	if err := s.repo.SetPostHidden(ctx, post.Id, *payload.Hidden); err != nil {
+		return fmt.Errorf("failed to update post visibility | %w", err)
+	}
+
+	action := repositories.MODERATION_UNHIDE_POST
+	if *payload.Hidden {
+		action = repositories.MODERATION_HIDE_POST
+	}
+
+	if err := s.repo.CreateModerationLog(ctx, repositories.CreateModerationLogPayload{
+		ActorId:    admin.Id,
+		Action:     action,
+		TargetType: repositories.MODERATION_TARGET_POST,
+		TargetId:   post.Id,
+		Note:       payload.Note,
+	}); err != nil {
+		return fmt.Errorf("failed to create moderation log | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Service) updateUserSuspension(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update user suspension |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating user suspension")
+		}
+	}()
+
+	payload := repositories.UpdateUserSuspensionPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Suspended is required")
+		return nil
+	}
+
+	admin, err := s.getUser(c)
+	if err != nil || admin == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	id := c.Param("id")
+	if id == admin.Id {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Cannot suspend your own account")
+		return nil
+	}
+
+	user, err := s.repo.GetUserById(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get user | %w", err)
+	}
+
+	if user == nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusNotFound, "User not found")
+		return nil
+	}
+
+	if err := s.repo.SetUserSuspended(ctx, id, *payload.Suspended); err != nil {
+		return fmt.Errorf("failed to update user suspension | %w", err)
+	}
+
+	action := repositories.MODERATION_UNSUSPEND_USER
+	if *payload.Suspended {
+		action = repositories.MODERATION_SUSPEND_USER
+	}
+
+	if err := s.repo.CreateModerationLog(ctx, repositories.CreateModerationLogPayload{
+		ActorId:    admin.Id,
+		Action:     action,
+		TargetType: repositories.MODERATION_TARGET_USER,
+		TargetId:   id,
+		Note:       payload.Note,
+	}); err != nil {
+		return fmt.Errorf("failed to create moderation log | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Service) getModerationLogs(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get moderation logs |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting moderation logs")
+		}
+	}()
+
+	logs, err := s.repo.GetModerationLogs(c, c.Request.URL.Query())
+	if err != nil {
+		return fmt.Errorf("failed to get moderation logs | %w", err)
+	}
+
+	if logs == nil {
+		logs = []repositories.ModerationLog{}
+	}
+
+	c.JSON(http.StatusOK, logs)
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateUserRoleRollsBackWithoutAuditLog(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.On("BeginTxn", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("RollbackTxn", mock.Anything).Return(nil)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "admin-uid").
+		Return(&repositories.User{Id: "admin", Role: repositories.ROLE_ADMIN}, nil)
+	mockRepo.
+		On("GetUserById", mock.Anything, "staff").
+		Return(&repositories.User{Id: "staff", Role: repositories.ROLE_USER}, nil)
+	mockRepo.
+		On("UpdateUserRole", mock.Anything, "staff", repositories.ROLE_SHOP_STAFF).
+		Return(nil)
+	mockRepo.
+		On("CreateModerationLog", mock.Anything, mock.Anything).
+		Return(errors.New("insert failed"))
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.PATCH("/admin/users/:id/role", func(c *gin.Context) { c.Set("googleAuthId", "admin-uid") }, svc.UpdateUserRole)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/admin/users/staff/role", strings.NewReader(`{"role":"shop_staff"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockRepo.AssertCalled(t, "RollbackTxn", mock.Anything)
+	mockRepo.AssertNotCalled(t, "CommitTxn", mock.Anything)
+}
@@ -0,0 +1,349 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+type CategoryNode struct {
+	repositories.Category
+	Children []CategoryNode `json:"children"`
+}
+
+func (s *Service) GetCategoryBreadcrumb(c *gin.Context) {
+	s.getCategoryBreadcrumb(c)
+}
+
+func (s *Service) CreateCategory(c *gin.Context) {
+	s.createCategory(c)
+}
+
+func (s *Service) UpdateCategory(c *gin.Context) {
+	s.updateCategory(c)
+}
+
+func (s *Service) RetireCategory(c *gin.Context) {
+	s.retireCategory(c)
+}
+
+func (s *Service) getCategoryBreadcrumb(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get category breadcrumb |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting category breadcrumb")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid category id")
+		return nil
+	}
+
+	categories, err := s.repo.GetCategoryBreadcrumb(localize(c), id)
+	if err != nil {
+		return fmt.Errorf("failed to get category breadcrumb | %w", err)
+	}
+
+	if len(categories) <= 0 {
+		c.JSON(http.StatusNotFound, "Category not found")
+		return nil
+	}
+
+	c.JSON(http.StatusOK, categories)
+
+	return nil
+}
+
+func (s *Service) createCategory(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to create category |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while creating category")
+		}
+	}()
+
+	payload := repositories.CreateCategoryPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, "Value and label are required")
+		return nil
+	}
+
+	payload.Value, payload.Label = strings.TrimSpace(payload.Value), strings.TrimSpace(payload.Label)
+	msg := validateValueLabel(payload.Value, payload.Label)
+	if msg == "" {
+		msg = validateLabels(payload.Labels)
+	}
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	if payload.ParentId != nil {
+		parent, err := s.repo.GetCategory(c, *payload.ParentId)
+		if err != nil {
+			return fmt.Errorf("failed to get parent category | %w", err)
+		}
+
+		if parent == nil || parent.RetiredAt != nil {
+			c.JSON(http.StatusBadRequest, "Invalid parent id")
+			return nil
+		}
+	}
+
+	exists, err := s.repo.CategoryExists(c, payload.Value, nil)
+	if err != nil {
+		return fmt.Errorf("failed to check category | %w", err)
+	}
+
+	if exists {
+		c.JSON(http.StatusConflict, "Category already exists")
+		return nil
+	}
+
+	id, err := s.repo.CreateCategory(c, payload)
+	if err != nil {
+		return fmt.Errorf("failed to create category | %w", err)
+	}
+
+	category, err := s.repo.GetCategory(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get category | %w", err)
+	}
+
+	c.JSON(http.StatusOK, category)
+
+	return nil
+}
+
+func (s *Service) updateCategory(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update category |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating category")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Invalid category id")
+		return nil
+	}
+
+	payload := repositories.UpdateCategoryPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Invalid payload")
+		return nil
+	}
+
+	category, err := s.repo.GetCategory(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get category | %w", err)
+	}
+
+	if category == nil || category.RetiredAt != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusNotFound, "Category not found")
+		return nil
+	}
+
+	value, label := *category.Value, *category.Label
+	if payload.Value != nil {
+		value = strings.TrimSpace(*payload.Value)
+		payload.Value = &value
+	}
+	if payload.Label != nil {
+		label = strings.TrimSpace(*payload.Label)
+		payload.Label = &label
+	}
+
+	msg := validateValueLabel(value, label)
+	if msg == "" {
+		msg = validateLabels(payload.Labels)
+	}
+	if msg != "" {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	if value != *category.Value {
+		exists, err := s.repo.CategoryExists(ctx, value, &id)
+		if err != nil {
+			return fmt.Errorf("failed to check category | %w", err)
+		}
+
+		if exists {
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusConflict, "Category already exists")
+			return nil
+		}
+	}
+
+	if payload.ParentId != nil {
+		breadcrumb, err := s.repo.GetCategoryBreadcrumb(ctx, *payload.ParentId)
+		if err != nil {
+			return fmt.Errorf("failed to get parent breadcrumb | %w", err)
+		}
+
+		if len(breadcrumb) <= 0 {
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusBadRequest, "Invalid parent id")
+			return nil
+		}
+
+		for idx := range breadcrumb {
+			if breadcrumb[idx].Id != nil && *breadcrumb[idx].Id == id {
+				s.repo.RollbackTxn(ctx)
+				c.JSON(http.StatusBadRequest, "Cannot move a category under itself or its subcategories")
+				return nil
+			}
+		}
+	}
+
+	if err := s.repo.UpdateCategory(ctx, id, payload); err != nil {
+		return fmt.Errorf("failed to update category | %w", err)
+	}
+
+	category, err = s.repo.GetCategory(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get category | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	c.JSON(http.StatusOK, category)
+
+	return nil
+}
+
+func (s *Service) retireCategory(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to retire category |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while retiring category")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Invalid category id")
+		return nil
+	}
+
+	category, err := s.repo.GetCategory(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get category | %w", err)
+	}
+
+	if category == nil || category.RetiredAt != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusNotFound, "Category not found")
+		return nil
+	}
+
+	categories, err := s.repo.GetCategories(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get categories | %w", err)
+	}
+
+	for idx := range categories {
+		if categories[idx].ParentId != nil && *categories[idx].ParentId == id {
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusConflict, "Category has subcategories")
+			return nil
+		}
+	}
+
+	count, err := s.repo.GetCategoryPostCount(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get category post count | %w", err)
+	}
+
+	if count > 0 {
+		reassignTo := c.Query("reassignTo")
+		if reassignTo == "" {
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusConflict, fmt.Sprintf("Category is used by %d posts", count))
+			return nil
+		}
+
+		targetId, err := strconv.Atoi(reassignTo)
+		if err != nil || targetId == id {
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusBadRequest, "Invalid reassignTo category id")
+			return nil
+		}
+
+		target, err := s.repo.GetCategory(ctx, targetId)
+		if err != nil {
+			return fmt.Errorf("failed to get target category | %w", err)
+		}
+
+		if target == nil || target.RetiredAt != nil {
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusBadRequest, "Invalid reassignTo category id")
+			return nil
+		}
+
+		if err := s.repo.ReassignCategoryPosts(ctx, id, targetId); err != nil {
+			return fmt.Errorf("failed to reassign category posts | %w", err)
+		}
+	}
+
+	if err := s.repo.RetireCategory(ctx, id); err != nil {
+		return fmt.Errorf("failed to retire category | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
+
+func buildCategoryTree(categories []repositories.Category) []CategoryNode {
+	children := map[int][]repositories.Category{}
+	roots := []repositories.Category{}
+	for idx := range categories {
+		if categories[idx].ParentId == nil {
+			roots = append(roots, categories[idx])
+			continue
+		}
+		parentId := *categories[idx].ParentId
+		children[parentId] = append(children[parentId], categories[idx])
+	}
+
+	var build func(categories []repositories.Category) []CategoryNode
+	build = func(categories []repositories.Category) []CategoryNode {
+		nodes := make([]CategoryNode, len(categories))
+		for idx := range categories {
+			nodes[idx] = CategoryNode{Category: categories[idx], Children: []CategoryNode{}}
+			if categories[idx].Id != nil {
+				nodes[idx].Children = build(children[*categories[idx].Id])
+			}
+		}
+		return nodes
+	}
+
+	return build(roots)
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBuildCategoryTree(t *testing.T) {
+	id := func(v int) *int { return &v }
+	value := func(v string) *string { return &v }
+
+	tree := buildCategoryTree([]repositories.Category{
+		{Id: id(1), Value: value("surfboard"), PostCount: id(3)},
+		{Id: id(2), Value: value("snowboard"), PostCount: id(1)},
+		{Id: id(3), ParentId: id(1), Value: value("shortboard"), PostCount: id(2)},
+		{Id: id(5), ParentId: id(3), Value: value("eps"), PostCount: id(2)},
+	})
+
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "surfboard", *tree[0].Value)
+	assert.Equal(t, 3, *tree[0].PostCount)
+	assert.Len(t, tree[0].Children, 1)
+	assert.Equal(t, "eps", *tree[0].Children[0].Children[0].Value)
+	assert.Empty(t, tree[1].Children)
+}
+
+func TestRetireCategoryInUse(t *testing.T) {
+	id, value := 5, "eps"
+
+	mockRepo := new(mocks.IRepository)
+	mockRepo.On("BeginTxn", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("RollbackTxn", mock.Anything).Return(nil)
+	mockRepo.
+		On("GetCategory", mock.Anything, 5).
+		Return(&repositories.Category{Id: &id, Value: &value}, nil)
+	mockRepo.
+		On("GetCategories", mock.Anything).
+		Return([]repositories.Category{{Id: &id, Value: &value}}, nil)
+	mockRepo.
+		On("GetCategoryPostCount", mock.Anything, 5).
+		Return(4, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.DELETE("/categories/:id", svc.RetireCategory)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/categories/5", nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockRepo.AssertNotCalled(t, "RetireCategory", mock.Anything, mock.Anything)
+}
+
+func TestUpdateCategoryMoveToRoot(t *testing.T) {
+	id, parentId, value, label := 5, 3, "eps", "EPS"
+
+	mockRepo := new(mocks.IRepository)
+	mockRepo.On("BeginTxn", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("CommitTxn", mock.Anything).Return(nil)
+	mockRepo.
+		On("GetCategory", mock.Anything, id).
+		Return(&repositories.Category{Id: &id, ParentId: &parentId, Value: &value, Label: &label}, nil)
+	mockRepo.
+		On("UpdateCategory", mock.Anything, id, mock.MatchedBy(func(payload repositories.UpdateCategoryPayload) bool {
+			return payload.ParentId == nil && payload.MoveToRoot
+		})).
+		Return(nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.PATCH("/admin/categories/:id", svc.UpdateCategory)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/admin/categories/5", strings.NewReader(`{"parentId":null}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertNumberOfCalls(t, "UpdateCategory", 1)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+func (s *Service) RegisterDevice(c *gin.Context) {
+	s.registerDevice(c)
+}
+
+func (s *Service) DeleteDevice(c *gin.Context) {
+	s.deleteDevice(c)
+}
+
+func (s *Service) registerDevice(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to register device |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while registering device")
+		}
+	}()
+
+	payload := repositories.RegisterDeviceTokenPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, "Token and platform (ios, android or web) are required")
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	payload.UserId = user.Id
+
+	if err := s.repo.RegisterDeviceToken(c, payload); err != nil {
+		return fmt.Errorf("failed to register device token | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Service) deleteDevice(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to delete device |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while deleting device")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	token := c.Param("token")
+	if err := s.repo.DeleteDeviceTokens(c, &user.Id, []string{token}); err != nil {
+		return fmt.Errorf("failed to delete device token | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
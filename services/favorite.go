@@ -0,0 +1,136 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+func (s *Service) AddFavorite(c *gin.Context) {
+	s.addFavorite(c)
+}
+
+func (s *Service) RemoveFavorite(c *gin.Context) {
+	s.removeFavorite(c)
+}
+
+func (s *Service) GetFavorites(c *gin.Context) {
+	s.getFavorites(c)
+}
+
+func (s *Service) addFavorite(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to add favorite |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while adding favorite")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	post, err := s.repo.GetPost(c, c.Param("id"))
+	if err != nil {
+		return fmt.Errorf("failed to get post | %w", err)
+	}
+
+	if post == nil || post.DeletedAt != nil || post.HiddenAt != nil || post.UserSuspendedAt != nil {
+		c.JSON(http.StatusNotFound, "Post not found")
+		return nil
+	}
+
+	if err := s.repo.CreateFavorite(c, user.Id, post.Id); err != nil {
+		return fmt.Errorf("failed to create favorite | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Service) removeFavorite(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to remove favorite |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while removing favorite")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	if err := s.repo.DeleteFavorite(c, user.Id, c.Param("id")); err != nil {
+		return fmt.Errorf("failed to delete favorite | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Service) getFavorites(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get favorites |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting favorites")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	posts, err := s.repo.GetFavoritePosts(localize(c), user.Id, c.Request.URL.Query())
+	if err != nil {
+		return fmt.Errorf("failed to get favorite posts | %w", err)
+	}
+
+	isFavorited := true
+	for idx := range posts {
+		posts[idx].IsFavorited = &isFavorited
+	}
+
+	if posts == nil {
+		posts = []repositories.Post{}
+	}
+
+	c.JSON(http.StatusOK, posts)
+
+	return nil
+}
+
+func (s *Service) setFavorited(c *gin.Context, posts []repositories.Post, viewer *repositories.User) error {
+	if viewer == nil || len(posts) <= 0 {
+		return nil
+	}
+
+	postIds := make([]string, len(posts))
+	for idx := range posts {
+		postIds[idx] = posts[idx].Id
+	}
+
+	ids, err := s.repo.GetFavoritedPostIds(c, viewer.Id, postIds)
+	if err != nil {
+		return fmt.Errorf("failed to get favorited post ids | %w", err)
+	}
+
+	favorited := map[string]bool{}
+	for idx := range ids {
+		favorited[ids[idx]] = true
+	}
+
+	for idx := range posts {
+		isFavorited := favorited[posts[idx].Id]
+		posts[idx].IsFavorited = &isFavorited
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPostsIsFavorited(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "renter-uid").
+		Return(&repositories.User{Id: "renter"}, nil)
+	mockRepo.
+		On("GetPosts", mock.Anything, mock.Anything).
+		Return([]repositories.Post{{Id: "post-1"}, {Id: "post-2"}}, nil)
+	mockRepo.
+		On("GetFavoritedPostIds", mock.Anything, "renter", []string{"post-1", "post-2"}).
+		Return([]string{"post-2"}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.GET("/posts", svc.GetPosts)
+	router.GET("/auth/posts", func(c *gin.Context) { c.Set("googleAuthId", "renter-uid") }, svc.GetPosts)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"isFavorited"`)
+	mockRepo.AssertNotCalled(t, "GetFavoritedPostIds", mock.Anything, mock.Anything, mock.Anything)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/posts", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Regexp(t, `"id":"post-1".*"isFavorited":false`, w.Body.String())
+	assert.Regexp(t, `"id":"post-2".*"isFavorited":true`, w.Body.String())
+}
+
+func TestAddFavorite(t *testing.T) {
+	hiddenAt, suspendedAt := time.Now(), time.Now()
+
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "renter-uid").
+		Return(&repositories.User{Id: "renter"}, nil)
+	mockRepo.
+		On("GetPost", mock.Anything, "post-1").
+		Return(&repositories.Post{Id: "post-1", UserId: "owner"}, nil)
+	mockRepo.
+		On("GetPost", mock.Anything, "hidden").
+		Return(&repositories.Post{Id: "hidden", UserId: "owner", HiddenAt: &hiddenAt}, nil)
+	mockRepo.
+		On("GetPost", mock.Anything, "suspended").
+		Return(&repositories.Post{Id: "suspended", UserId: "owner", UserSuspendedAt: &suspendedAt}, nil)
+	mockRepo.
+		On("GetPost", mock.Anything, "missing").
+		Return(nil, nil)
+	mockRepo.
+		On("CreateFavorite", mock.Anything, "renter", "post-1").
+		Return(nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.POST("/posts/:id/favorite", func(c *gin.Context) { c.Set("googleAuthId", "renter-uid") }, svc.AddFavorite)
+
+	cases := []struct {
+		postId   string
+		expected int
+	}{
+		{"post-1", http.StatusNoContent},
+		{"hidden", http.StatusNotFound},
+		{"suspended", http.StatusNotFound},
+		{"missing", http.StatusNotFound},
+	}
+
+	for idx := range cases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/posts/"+cases[idx].postId+"/favorite", nil))
+
+		assert.Equal(t, cases[idx].expected, w.Code, cases[idx].postId)
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "CreateFavorite", 1)
+}
+
+func TestRemoveFavorite(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "renter-uid").
+		Return(&repositories.User{Id: "renter"}, nil)
+	mockRepo.
+		On("DeleteFavorite", mock.Anything, "renter", "post-1").
+		Return(nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.DELETE("/posts/:id/favorite", func(c *gin.Context) { c.Set("googleAuthId", "renter-uid") }, svc.RemoveFavorite)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/posts/post-1/favorite", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockRepo.AssertCalled(t, "DeleteFavorite", mock.Anything, "renter", "post-1")
+}
+
+func TestGetFavorites(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "renter-uid").
+		Return(&repositories.User{Id: "renter"}, nil)
+	mockRepo.
+		On("GetFavoritePosts", mock.Anything, "renter", mock.Anything).
+		Return([]repositories.Post{{Id: "post-1"}}, nil)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "empty-uid").
+		Return(&repositories.User{Id: "empty"}, nil)
+	mockRepo.
+		On("GetFavoritePosts", mock.Anything, "empty", mock.Anything).
+		Return(nil, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.GET("/user/favorites", func(c *gin.Context) { c.Set("googleAuthId", c.Query("uid")) }, svc.GetFavorites)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user/favorites?uid=renter-uid", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"isFavorited":true`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user/favorites?uid=empty-uid", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]", w.Body.String())
+}
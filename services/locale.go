@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mailer"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+func getLocale(c *gin.Context) string {
+	if lang := strings.ToLower(c.Query("lang")); SUPPORTED_LOCALES[lang] {
+		return lang
+	}
+
+	type language struct {
+		locale string
+		q      float64
+	}
+
+	languages := []language{}
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		locale := strings.ToLower(strings.SplitN(strings.TrimSpace(fields[0]), "-", 2)[0])
+		if !SUPPORTED_LOCALES[locale] {
+			continue
+		}
+
+		q := 1.0
+		for _, field := range fields[1:] {
+			field = strings.TrimSpace(field)
+			if strings.HasPrefix(field, "q=") {
+				if v, err := strconv.ParseFloat(field[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		if q > 0 {
+			languages = append(languages, language{locale, q})
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].q > languages[j].q
+	})
+
+	if len(languages) > 0 {
+		return languages[0].locale
+	}
+
+	return mailer.DEFAULT_LOCALE
+}
+
+func localize(c *gin.Context) context.Context {
+	locale := getLocale(c)
+	c.Header("Content-Language", locale)
+
+	return repositories.WithLocale(c, locale)
+}
+
+func validateLabels(labels map[string]string) string {
+	for locale, label := range labels {
+		if !SUPPORTED_LOCALES[locale] {
+			return fmt.Sprintf("Unsupported locale: %s", locale)
+		}
+
+		label = strings.TrimSpace(label)
+		if label == "" || utf8.RuneCountInString(label) > MAX_VALUE_LABEL_LENGTH {
+			return fmt.Sprintf("Label must be between 1 and %d characters", MAX_VALUE_LABEL_LENGTH)
+		}
+		labels[locale] = label
+	}
+
+	return ""
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLocale(t *testing.T) {
+	cases := []struct {
+		query    string
+		header   string
+		expected string
+	}{
+		{"", "", "ja"},
+		{"", "en-US,en;q=0.9", "en"},
+		{"", "fr-FR,fr;q=0.9,en;q=0.8,ja;q=0.5", "en"},
+		{"", "ja;q=0.4,en;q=0.8", "en"},
+		{"", "en;q=0", "ja"},
+		{"?lang=en", "ja", "en"},
+		{"?lang=fr", "en", "en"},
+	}
+
+	for idx := range cases {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/tags"+cases[idx].query, nil)
+		c.Request.Header.Set("Accept-Language", cases[idx].header)
+
+		assert.Equal(t, cases[idx].expected, getLocale(c), cases[idx].query+" "+cases[idx].header)
+	}
+}
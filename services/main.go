@@ -1,13 +1,22 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/katakeda/boardhop-api-service-go/mailer"
+	"github.com/katakeda/boardhop-api-service-go/push"
 	"github.com/katakeda/boardhop-api-service-go/repositories"
 )
 
 type Service struct {
-	repo repositories.IRepository
+	repo   repositories.IRepository
+	hub    *messageHub
+	filter *messageFilter
+	mailer mailer.Mailer
+	push   push.Sender
 }
 
 func NewService(repo repositories.IRepository) (*Service, error) {
@@ -15,7 +24,21 @@ func NewService(repo repositories.IRepository) (*Service, error) {
 		return nil, fmt.Errorf("repository is required to start a new service")
 	}
 
+	m, err := mailer.NewMailer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize mailer | %w", err)
+	}
+
+	p, err := push.NewSender(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize push sender | %w", err)
+	}
+
 	return &Service{
-		repo: repo,
+		repo:   repo,
+		hub:    newMessageHub(),
+		filter: newMessageFilter(strings.Split(os.Getenv("MESSAGE_FILTER_WORDS"), ",")),
+		mailer: mailer.NewAsyncMailer(m, 2, 100),
+		push:   p,
 	}, nil
 }
@@ -1,18 +1,140 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mailer"
 	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/katakeda/boardhop-api-service-go/utils"
 )
 
+const (
+	MAX_MESSAGE_LENGTH      = 2000
+	MAX_MESSAGE_ATTACHMENTS = 4
+	MAX_ATTACHMENT_SIZE     = 10 << 20
+)
+
+func (s *Service) GetOrderMessages(c *gin.Context) {
+	s.getOrderMessages(c)
+}
+
+func (s *Service) GetPostMessages(c *gin.Context) {
+	s.getPostMessages(c)
+}
+
 func (s *Service) CreateMessage(c *gin.Context) {
 	s.createMessage(c)
 }
 
+func (s *Service) MarkOrderMessagesRead(c *gin.Context) {
+	s.markOrderMessagesRead(c)
+}
+
+func (s *Service) MarkPostMessagesRead(c *gin.Context) {
+	s.markPostMessagesRead(c)
+}
+
+func (s *Service) GetUnreadCounts(c *gin.Context) {
+	s.getUnreadCounts(c)
+}
+
+func (s *Service) getOrderMessages(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get order messages |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting messages")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	id := c.Param("id")
+	order, err := s.repo.GetOrder(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get order | %w", err)
+	}
+
+	if order == nil {
+		c.JSON(http.StatusNotFound, "Order not found")
+		return nil
+	}
+
+	if !isOrderParty(order, user) {
+		c.JSON(http.StatusForbidden, "Not allowed to view these messages")
+		return nil
+	}
+
+	params := c.Request.URL.Query()
+	messages, err := s.repo.GetMessages(c, repositories.GetMessagesFilter{OrderId: &order.Id}, params)
+	if err != nil {
+		return fmt.Errorf("failed to get messages | %w", err)
+	}
+
+	c.JSON(http.StatusOK, messages)
+
+	return nil
+}
+
+func (s *Service) getPostMessages(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get post messages |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting messages")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	id := c.Param("id")
+	post, err := s.repo.GetPost(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get post | %w", err)
+	}
+
+	if post == nil {
+		c.JSON(http.StatusNotFound, "Post not found")
+		return nil
+	}
+
+	if post.UserId == user.Id && c.Query("askerId") == "" {
+		c.JSON(http.StatusBadRequest, "askerId is required")
+		return nil
+	}
+
+	askerId, allowed := postThreadAsker(post, user, c.Query("askerId"))
+	if !allowed {
+		c.JSON(http.StatusForbidden, "Not allowed to view these messages")
+		return nil
+	}
+
+	params := c.Request.URL.Query()
+	messages, err := s.repo.GetMessages(c, repositories.GetMessagesFilter{PostId: &post.Id, AskerId: &askerId}, params)
+	if err != nil {
+		return fmt.Errorf("failed to get messages | %w", err)
+	}
+
+	c.JSON(http.StatusOK, messages)
+
+	return nil
+}
+
 func (s *Service) createMessage(c *gin.Context) (err error) {
 	defer func() {
 		if err != nil {
@@ -22,7 +144,7 @@ func (s *Service) createMessage(c *gin.Context) (err error) {
 	}()
 
 	payload := repositories.CreateMessagePayload{}
-	if err := c.BindJSON(&payload); err != nil {
+	if err := c.ShouldBind(&payload); err != nil {
 		return fmt.Errorf("failed to parse payload | %w", err)
 	}
 
@@ -31,14 +153,342 @@ func (s *Service) createMessage(c *gin.Context) (err error) {
 		return fmt.Errorf("failed to authorize user | %w", err)
 	}
 
+	if (payload.OrderId == nil) == (payload.PostId == nil) {
+		c.JSON(http.StatusBadRequest, "Exactly one of orderId or postId is required")
+		return nil
+	}
+
+	if msg := validateMessagePayload(payload); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	var order *repositories.Order
+	var post *repositories.Post
+
+	allowed, mask := false, true
+	if payload.OrderId != nil {
+		order, err = s.repo.GetOrder(c, *payload.OrderId)
+		if err != nil {
+			return fmt.Errorf("failed to get order | %w", err)
+		}
+		if order == nil {
+			c.JSON(http.StatusNotFound, "Order not found")
+			return nil
+		}
+		allowed = isOrderParty(order, user)
+		mask = !ACCEPTED_ORDER_STATUSES[order.Status]
+	} else {
+		post, err = s.repo.GetPost(c, *payload.PostId)
+		if err != nil {
+			return fmt.Errorf("failed to get post | %w", err)
+		}
+		if post == nil || post.DeletedAt != nil || post.HiddenAt != nil || post.UserSuspendedAt != nil {
+			c.JSON(http.StatusNotFound, "Post not found")
+			return nil
+		}
+		askerId := ""
+		if payload.AskerId != nil {
+			askerId = *payload.AskerId
+		}
+		if askerId, allowed = postThreadAsker(post, user, askerId); allowed && post.UserId == user.Id {
+			thread, err := s.repo.GetMessages(c, repositories.GetMessagesFilter{PostId: &post.Id, AskerId: &askerId}, url.Values{"l": {"1"}})
+			if err != nil {
+				return fmt.Errorf("failed to get thread | %w", err)
+			}
+			allowed = len(thread) > 0
+		}
+		payload.AskerId = &askerId
+	}
+
+	if !allowed {
+		c.JSON(http.StatusForbidden, "Not allowed to send this message")
+		return nil
+	}
+
 	payload.UserId = user.Id
 
-	message, err := s.repo.CreateMessage(c, payload)
+	var matches []string
+	payload.Message, matches = s.screenMessage(payload.Message, mask)
+
+	conversationId := payload.PostId
+	if payload.OrderId != nil {
+		conversationId = payload.OrderId
+	}
+
+	objects, err := uploadMessageAttachments(c, *conversationId, payload.Attachments)
 	if err != nil {
+		return fmt.Errorf("failed to upload attachments | %w", err)
+	}
+
+	ctx, err := s.repo.BeginTxn(c)
+	if err != nil {
+		deleteMessageAttachments(c, objects)
+		return fmt.Errorf("failed to begin db txn | %w", err)
+	}
+
+	message, err := s.repo.CreateMessage(ctx, payload)
+	if err != nil {
+		s.repo.RollbackTxn(ctx)
+		deleteMessageAttachments(c, objects)
 		return fmt.Errorf("failed to insert message | %w", err)
 	}
 
+	if len(matches) > 0 {
+		flag := repositories.CreateMessageFlagPayload{MessageId: message.Id, Reason: "filtered_words", Matches: matches}
+		if err = s.repo.CreateMessageFlag(ctx, flag); err != nil {
+			s.repo.RollbackTxn(ctx)
+			deleteMessageAttachments(c, objects)
+			return fmt.Errorf("failed to flag message | %w", err)
+		}
+	}
+
+	attachments := []repositories.CreateMessageAttachment{}
+	for idx := range objects {
+		attachments = append(attachments, repositories.CreateMessageAttachment{
+			MessageId: message.Id,
+			MediaUrl:  utils.GetObjectUrl(objects[idx]),
+			Type:      "image",
+		})
+	}
+
+	if len(attachments) > 0 {
+		if err = s.repo.CreateMessageAttachments(ctx, attachments); err != nil {
+			s.repo.RollbackTxn(ctx)
+			deleteMessageAttachments(c, objects)
+			return fmt.Errorf("failed to create message attachments | %w", err)
+		}
+	}
+
+	if err = s.repo.CommitTxn(ctx); err != nil {
+		deleteMessageAttachments(c, objects)
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	if created, err := s.repo.GetMessage(c, message.Id); err == nil && created != nil {
+		message = created
+	}
+
 	c.JSON(http.StatusOK, message)
 
+	n := notification{
+		Template: mailer.TEMPLATE_NEW_MESSAGE,
+		Actor:    user,
+		Message:  payload.Message,
+	}
+	if order != nil {
+		n.UserId, n.Post, n.Order, n.Path = order.UserId, &order.Post, order, "/orders/"+order.Id
+		if user.Id == order.UserId {
+			n.UserId = order.Post.UserId
+		}
+	} else {
+		n.UserId, n.Post, n.Path = post.UserId, post, "/posts/"+post.Id
+		if user.Id == post.UserId {
+			n.UserId = *payload.AskerId
+		}
+	}
+	if n.UserId != "" {
+		s.notify(n)
+	}
+
+	return nil
+}
+
+func (s *Service) markOrderMessagesRead(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to mark order messages read |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while marking messages read")
+		}
+	}()
+
+	payload := repositories.MarkMessagesReadPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse payload | %w", err)
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	id := c.Param("id")
+	order, err := s.repo.GetOrder(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get order | %w", err)
+	}
+
+	if order == nil {
+		c.JSON(http.StatusNotFound, "Order not found")
+		return nil
+	}
+
+	if !isOrderParty(order, user) {
+		c.JSON(http.StatusForbidden, "Not allowed to read these messages")
+		return nil
+	}
+
+	payload.UserId = user.Id
+	payload.OrderId = &order.Id
+
+	if err := s.repo.MarkMessagesRead(c, payload); err != nil {
+		return fmt.Errorf("failed to mark messages read | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Service) markPostMessagesRead(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to mark post messages read |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while marking messages read")
+		}
+	}()
+
+	payload := repositories.MarkMessagesReadPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse payload | %w", err)
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	id := c.Param("id")
+	post, err := s.repo.GetPost(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get post | %w", err)
+	}
+
+	if post == nil {
+		c.JSON(http.StatusNotFound, "Post not found")
+		return nil
+	}
+
+	if post.UserId == user.Id && c.Query("askerId") == "" {
+		c.JSON(http.StatusBadRequest, "askerId is required")
+		return nil
+	}
+
+	askerId, allowed := postThreadAsker(post, user, c.Query("askerId"))
+	if !allowed {
+		c.JSON(http.StatusForbidden, "Not allowed to read these messages")
+		return nil
+	}
+
+	payload.UserId = user.Id
+	payload.PostId = &post.Id
+	payload.AskerId = &askerId
+
+	if err := s.repo.MarkMessagesRead(c, payload); err != nil {
+		return fmt.Errorf("failed to mark messages read | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
 	return nil
 }
+
+func (s *Service) getUnreadCounts(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get unread counts |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting unread counts")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	counts, err := s.repo.GetUnreadCounts(c, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get unread counts | %w", err)
+	}
+
+	c.JSON(http.StatusOK, counts)
+
+	return nil
+}
+
+func validateMessagePayload(payload repositories.CreateMessagePayload) string {
+	if (payload.Message == nil || strings.TrimSpace(*payload.Message) == "") && len(payload.Attachments) <= 0 {
+		return "Message or attachment is required"
+	}
+
+	if payload.Message != nil && utf8.RuneCountInString(*payload.Message) > MAX_MESSAGE_LENGTH {
+		return fmt.Sprintf("Message must be %d characters or less", MAX_MESSAGE_LENGTH)
+	}
+
+	if len(payload.Attachments) > MAX_MESSAGE_ATTACHMENTS {
+		return fmt.Sprintf("No more than %d attachments are allowed", MAX_MESSAGE_ATTACHMENTS)
+	}
+
+	for idx := range payload.Attachments {
+		if payload.Attachments[idx].Size > MAX_ATTACHMENT_SIZE {
+			return fmt.Sprintf("Attachment %s is too large", payload.Attachments[idx].Filename)
+		}
+		if !strings.HasPrefix(payload.Attachments[idx].Header.Get("Content-Type"), "image/") {
+			return fmt.Sprintf("Attachment %s is not an image", payload.Attachments[idx].Filename)
+		}
+	}
+
+	return ""
+}
+
+func uploadMessageAttachments(c *gin.Context, conversationId string, files []*multipart.FileHeader) (objects []string, err error) {
+	if len(files) <= 0 {
+		return []string{}, nil
+	}
+
+	bucket, err := utils.GetDefaultBucket(c)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range files {
+		object := fmt.Sprintf("messages/%s/%d-%d%s", conversationId, time.Now().UnixNano(), idx, filepath.Ext(files[idx].Filename))
+		if err := utils.UploadFile(c, bucket, object, files[idx]); err != nil {
+			deleteMessageAttachments(c, objects)
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+func deleteMessageAttachments(c *gin.Context, objects []string) {
+	if len(objects) <= 0 {
+		return
+	}
+
+	bucket, err := utils.GetDefaultBucket(c)
+	if err != nil {
+		log.Println("Failed to clean up attachments |", err)
+		return
+	}
+
+	for idx := range objects {
+		if err := utils.DeleteFile(c, bucket, objects[idx]); err != nil {
+			log.Println("Failed to clean up attachment |", err)
+		}
+	}
+}
+
+func isOrderParty(order *repositories.Order, user *repositories.User) bool {
+	return order.UserId == user.Id || order.Post.UserId == user.Id
+}
+
+func postThreadAsker(post *repositories.Post, user *repositories.User, askerId string) (string, bool) {
+	if post.UserId == user.Id {
+		return askerId, askerId != "" && askerId != user.Id
+	}
+
+	return user.Id, askerId == "" || askerId == user.Id
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	MASK = "***"
+)
+
+var (
+	emailPattern   = regexp.MustCompile(`[A-Za-z0-9._%+\-]+\s*(?:@|＠|\(at\)|\[at\])\s*[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	phonePattern   = regexp.MustCompile(`[+＋]?[0-9０-９](?:[\s\-－ー‐()（）.]*[0-9０-９]){9,}`)
+	lineIdPattern  = regexp.MustCompile(`(?i)((?:\bline|ライン)\s*(?:(?:id|ＩＤ)\s*(?:[:：=]|は)?|[:：=]|は)\s*)@?[A-Za-z0-9._\-]{3,}`)
+	lineUrlPattern = regexp.MustCompile(`(?i)(?:https?://)?line\.me/\S+`)
+
+	ACCEPTED_ORDER_STATUSES = map[string]bool{
+		"accepted": true,
+		"complete": true,
+	}
+)
+
+type messageFilter struct {
+	words []string
+}
+
+func newMessageFilter(words []string) *messageFilter {
+	filter := &messageFilter{}
+	for idx := range words {
+		if word := strings.ToLower(strings.TrimSpace(words[idx])); word != "" {
+			filter.words = append(filter.words, word)
+		}
+	}
+
+	return filter
+}
+
+func (f *messageFilter) matches(message string) []string {
+	lower := strings.ToLower(message)
+
+	matches := []string{}
+	for idx := range f.words {
+		if strings.Contains(lower, f.words[idx]) {
+			matches = append(matches, f.words[idx])
+		}
+	}
+
+	return matches
+}
+
+func maskContactInfo(message string) (string, bool) {
+	masked := lineUrlPattern.ReplaceAllString(message, MASK)
+	masked = emailPattern.ReplaceAllString(masked, MASK)
+	masked = lineIdPattern.ReplaceAllString(masked, "${1}"+MASK)
+	masked = phonePattern.ReplaceAllString(masked, MASK)
+
+	return masked, masked != message
+}
+
+func (s *Service) screenMessage(message *string, mask bool) (*string, []string) {
+	if message == nil {
+		return nil, []string{}
+	}
+
+	screened := *message
+	if mask {
+		screened, _ = maskContactInfo(screened)
+	}
+
+	return &screened, s.filter.matches(*message)
+}
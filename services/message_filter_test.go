@@ -0,0 +1,39 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskContactInfo(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"電話は090-1234-5678です", "電話は***です"},
+		{"call +81 90 1234 5678", "call ***"},
+		{"０９０１２３４５６７８に連絡して", "***に連絡して"},
+		{"mail me at surfer.taro@example.co.jp", "mail me at ***"},
+		{"surfer (at) example.com", "***"},
+		{"LINE ID: taro_surf", "LINE ID: ***"},
+		{"ラインはtaro123", "ラインは***"},
+		{"https://line.me/ti/p/abcdef", "***"},
+		{"line: @taro", "line: ***"},
+		{"I'm online now, the line was long", "I'm online now, the line was long"},
+		{"Pickup at 10:30 on 2023-04-01, total 12000 yen", "Pickup at 10:30 on 2023-04-01, total 12000 yen"},
+	}
+
+	for idx := range cases {
+		masked, changed := maskContactInfo(cases[idx].input)
+		assert.Equal(t, cases[idx].expected, masked, cases[idx].input)
+		assert.Equal(t, cases[idx].input != cases[idx].expected, changed, cases[idx].input)
+	}
+}
+
+func TestMessageFilterMatches(t *testing.T) {
+	filter := newMessageFilter([]string{" Idiot ", "", "scam"})
+
+	assert.Equal(t, []string{"idiot"}, filter.matches("You IDIOT"))
+	assert.Equal(t, []string{}, filter.matches("Nice board"))
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateMessageRejectsNonParty(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "stranger-uid").
+		Return(&repositories.User{Id: "stranger"}, nil)
+	mockRepo.
+		On("GetOrder", mock.Anything, "order-1").
+		Return(&repositories.Order{Id: "order-1", UserId: "renter", Post: repositories.Post{UserId: "owner"}}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.POST("/messages", func(c *gin.Context) { c.Set("googleAuthId", "stranger-uid") }, svc.CreateMessage)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/messages", strings.NewReader(`{"orderId":"order-1","message":"hi"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockRepo.AssertNotCalled(t, "CreateMessage", mock.Anything, mock.Anything)
+}
+
+func TestOrderMessagesUnknownOrder(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "renter-uid").
+		Return(&repositories.User{Id: "renter"}, nil)
+	mockRepo.
+		On("GetOrder", mock.Anything, "missing").
+		Return(nil, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("googleAuthId", "renter-uid") })
+	router.GET("/orders/:id/messages", svc.GetOrderMessages)
+	router.POST("/messages", svc.CreateMessage)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/missing/messages", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/messages", strings.NewReader(`{"orderId":"missing","message":"hi"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockRepo.AssertNotCalled(t, "CreateMessage", mock.Anything, mock.Anything)
+}
+
+func TestGetPostMessagesScopedToAsker(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "asker-uid").
+		Return(&repositories.User{Id: "asker"}, nil)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "owner-uid").
+		Return(&repositories.User{Id: "owner"}, nil)
+	mockRepo.
+		On("GetPost", mock.Anything, "post-1").
+		Return(&repositories.Post{Id: "post-1", UserId: "owner"}, nil)
+	mockRepo.
+		On("GetMessages", mock.Anything, mock.MatchedBy(func(filter repositories.GetMessagesFilter) bool {
+			return *filter.PostId == "post-1" && filter.AskerId != nil && *filter.AskerId == "asker"
+		}), mock.Anything).
+		Return([]repositories.Message{}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	cases := []struct {
+		uid      string
+		query    string
+		expected int
+	}{
+		{"asker-uid", "", http.StatusOK},
+		{"asker-uid", "?askerId=someone-else", http.StatusForbidden},
+		{"owner-uid", "", http.StatusBadRequest},
+		{"owner-uid", "?askerId=asker", http.StatusOK},
+	}
+
+	for idx := range cases {
+		uid := cases[idx].uid
+		router := gin.New()
+		router.GET("/posts/:id/messages", func(c *gin.Context) { c.Set("googleAuthId", uid) }, svc.GetPostMessages)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts/post-1/messages"+cases[idx].query, nil))
+
+		assert.Equal(t, cases[idx].expected, w.Code, uid+cases[idx].query)
+	}
+
+	mockRepo.AssertNotCalled(t, "GetOrders", mock.Anything, mock.Anything)
+}
+
+func TestMarkOrderMessagesRead(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "renter-uid").
+		Return(&repositories.User{Id: "renter"}, nil)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "stranger-uid").
+		Return(&repositories.User{Id: "stranger"}, nil)
+	mockRepo.
+		On("GetOrder", mock.Anything, "order-1").
+		Return(&repositories.Order{Id: "order-1", UserId: "renter", Post: repositories.Post{UserId: "owner"}}, nil)
+	mockRepo.
+		On("MarkMessagesRead", mock.Anything, mock.MatchedBy(func(payload repositories.MarkMessagesReadPayload) bool {
+			return payload.UserId == "renter" && *payload.OrderId == "order-1" && payload.PostId == nil && *payload.MessageId == 5
+		})).
+		Return(nil)
+
+	svc, _ := NewService(mockRepo)
+
+	cases := []struct {
+		uid      string
+		expected int
+	}{
+		{"stranger-uid", http.StatusForbidden},
+		{"renter-uid", http.StatusNoContent},
+	}
+
+	for idx := range cases {
+		uid := cases[idx].uid
+		router := gin.New()
+		router.POST("/orders/:id/messages/read", func(c *gin.Context) { c.Set("googleAuthId", uid) }, svc.MarkOrderMessagesRead)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/orders/order-1/messages/read", strings.NewReader(`{"messageId":5}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, cases[idx].expected, w.Code, uid)
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "MarkMessagesRead", 1)
+}
+
+func TestGetUnreadCounts(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	postId, askerId, orderId := "post-1", "asker", "order-1"
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "owner-uid").
+		Return(&repositories.User{Id: "owner"}, nil)
+	mockRepo.
+		On("GetUnreadCounts", mock.Anything, "owner").
+		Return([]repositories.UnreadCount{
+			{OrderId: &orderId, Count: 2},
+			{PostId: &postId, AskerId: &askerId, Count: 1},
+		}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.GET("/messages/unread", func(c *gin.Context) { c.Set("googleAuthId", "owner-uid") }, svc.GetUnreadCounts)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/messages/unread", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[
+		{"postId":null,"askerId":null,"orderId":"order-1","count":2},
+		{"postId":"post-1","askerId":"asker","orderId":null,"count":1}
+	]`, w.Body.String())
+}
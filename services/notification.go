@@ -0,0 +1,106 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Service) GetNotifications(c *gin.Context) {
+	s.getNotifications(c)
+}
+
+func (s *Service) GetUnreadNotificationCount(c *gin.Context) {
+	s.getUnreadNotificationCount(c)
+}
+
+func (s *Service) MarkNotificationRead(c *gin.Context) {
+	s.markNotificationsRead(c, false)
+}
+
+func (s *Service) MarkAllNotificationsRead(c *gin.Context) {
+	s.markNotificationsRead(c, true)
+}
+
+func (s *Service) getNotifications(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get notifications |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting notifications")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	params := c.Request.URL.Query()
+
+	notifications, err := s.repo.GetNotifications(c, user.Id, params)
+	if err != nil {
+		return fmt.Errorf("failed to get notifications | %w", err)
+	}
+
+	c.JSON(http.StatusOK, notifications)
+
+	return nil
+}
+
+func (s *Service) getUnreadNotificationCount(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get unread notification count |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting unread notification count")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	count, err := s.repo.GetUnreadNotificationCount(c, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get unread notification count | %w", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+
+	return nil
+}
+
+func (s *Service) markNotificationsRead(c *gin.Context, all bool) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to mark notifications read |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while marking notifications read")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	var id *int
+	if !all {
+		v, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, "Invalid notification id")
+			return nil
+		}
+		id = &v
+	}
+
+	if err := s.repo.MarkNotificationsRead(c, user.Id, id); err != nil {
+		return fmt.Errorf("failed to mark notifications read | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
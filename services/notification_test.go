@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mailer"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateNotificationUsesRecipientLocale(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("CreateNotification", mock.Anything, mock.MatchedBy(func(payload repositories.CreateNotificationPayload) bool {
+			return payload.UserId == "owner" &&
+				payload.Type == mailer.TEMPLATE_ORDER_REQUESTED &&
+				payload.Body == "New booking request for 'Longboard'" &&
+				*payload.Target == "/orders/order-1"
+		})).
+		Return(nil)
+
+	svc, _ := NewService(mockRepo)
+
+	n := notification{
+		UserId:   "owner",
+		Template: mailer.TEMPLATE_ORDER_REQUESTED,
+		Post:     &repositories.Post{Title: "Longboard"},
+		Path:     "/orders/order-1",
+	}
+
+	err := svc.createNotification(context.Background(), n, &repositories.NotificationPreference{Locale: "en"})
+	assert.Nil(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMarkNotificationsRead(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "user-uid").
+		Return(&repositories.User{Id: "user"}, nil)
+	mockRepo.
+		On("MarkNotificationsRead", mock.Anything, "user", mock.MatchedBy(func(id *int) bool {
+			return id != nil && *id == 3
+		})).
+		Return(nil)
+	mockRepo.
+		On("MarkNotificationsRead", mock.Anything, "user", (*int)(nil)).
+		Return(nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	auth := func(c *gin.Context) { c.Set("googleAuthId", "user-uid") }
+	router.POST("/notifications/read", auth, svc.MarkAllNotificationsRead)
+	router.POST("/notifications/:id/read", auth, svc.MarkNotificationRead)
+
+	cases := []struct {
+		path     string
+		expected int
+	}{
+		{"/notifications/abc/read", http.StatusBadRequest},
+		{"/notifications/3/read", http.StatusNoContent},
+		{"/notifications/read", http.StatusNoContent},
+	}
+
+	for idx := range cases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, cases[idx].path, nil))
+
+		assert.Equal(t, cases[idx].expected, w.Code, cases[idx].path)
+	}
+
+	mockRepo.AssertNumberOfCalls(t, "MarkNotificationsRead", 2)
+}
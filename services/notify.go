@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/katakeda/boardhop-api-service-go/mailer"
+	"github.com/katakeda/boardhop-api-service-go/push"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+const (
+	PICKUP_REMINDER_INTERVAL = 15 * time.Minute
+	PICKUP_REMINDER_WINDOW   = 24 * time.Hour
+	MAX_PUSH_BODY_LENGTH     = 140
+)
+
+var (
+	SUPPORTED_LOCALES = map[string]bool{
+		"ja": true,
+		"en": true,
+	}
+
+	PUSH_TEXTS = map[string]map[string]string{
+		"ja": {
+			mailer.TEMPLATE_ORDER_REQUESTED: "「%s」に予約リクエストが届きました",
+			mailer.TEMPLATE_ORDER_ACCEPTED:  "「%s」の予約が承認されました",
+			mailer.TEMPLATE_ORDER_CANCELED:  "「%s」の予約がキャンセルされました",
+			mailer.TEMPLATE_PICKUP_REMINDER: "「%s」の受け渡しが近づいています",
+			mailer.TEMPLATE_NEW_MESSAGE:     "「%s」について新着メッセージがあります",
+			mailer.TEMPLATE_REVIEW_REQUEST:  "「%s」のレンタルはいかがでしたか？レビューを書きましょう",
+			mailer.TEMPLATE_REVIEW_RECEIVED: "「%s」の取引でレビューが届きました",
+
+			mailer.TEMPLATE_SAVED_SEARCH_DIGEST: "保存した検索「%[2]s」に新着が%[1]d件あります",
+			mailer.TEMPLATE_MODERATION_WARNING:  "出品「%s」について運営から警告が届いています",
+		},
+		"en": {
+			mailer.TEMPLATE_ORDER_REQUESTED: "New booking request for '%s'",
+			mailer.TEMPLATE_ORDER_ACCEPTED:  "Your booking for '%s' was accepted",
+			mailer.TEMPLATE_ORDER_CANCELED:  "Booking for '%s' was canceled",
+			mailer.TEMPLATE_PICKUP_REMINDER: "Pickup for '%s' is coming up",
+			mailer.TEMPLATE_NEW_MESSAGE:     "New message about '%s'",
+			mailer.TEMPLATE_REVIEW_REQUEST:  "How did the rental of '%s' go? Leave a review",
+			mailer.TEMPLATE_REVIEW_RECEIVED: "You received a review for '%s'",
+
+			mailer.TEMPLATE_SAVED_SEARCH_DIGEST: "%d new posts match your saved search '%s'",
+			mailer.TEMPLATE_MODERATION_WARNING:  "You received a warning about your listing '%s'",
+		},
+	}
+)
+
+type notification struct {
+	UserId   string
+	Template string
+	Actor    *repositories.User
+	Post     *repositories.Post
+	Order    *repositories.Order
+	Message  *string
+	Path     string
+
+	SavedSearch *repositories.SavedSearch
+	Posts       []repositories.Post
+}
+
+func (s *Service) notify(n notification) {
+	go func() {
+		ctx := context.Background()
+
+		preference, err := s.repo.GetNotificationPreference(ctx, n.UserId)
+		if err != nil {
+			log.Println("Failed to get notification preference |", n.UserId, err)
+			return
+		}
+
+		if err := s.createNotification(ctx, n, preference); err != nil {
+			log.Println("Failed to create in-app notification |", n.Template, n.UserId, err)
+		}
+
+		if err := s.sendEmail(ctx, n, preference); err != nil {
+			log.Println("Failed to send email notification |", n.Template, n.UserId, err)
+		}
+
+		if err := s.sendPush(ctx, n, preference); err != nil {
+			log.Println("Failed to send push notification |", n.Template, n.UserId, err)
+		}
+	}()
+}
+
+func (s *Service) createNotification(ctx context.Context, n notification, preference *repositories.NotificationPreference) error {
+	message := pushMessage(preference.Locale, n)
+
+	return s.repo.CreateNotification(ctx, repositories.CreateNotificationPayload{
+		UserId: n.UserId,
+		Type:   n.Template,
+		Title:  message.Title,
+		Body:   message.Body,
+		Target: &n.Path,
+	})
+}
+
+func (s *Service) sendEmail(ctx context.Context, n notification, preference *repositories.NotificationPreference) error {
+	if !emailEnabled(preference, n.Template) {
+		return nil
+	}
+
+	recipient, err := s.repo.GetUserById(ctx, n.UserId)
+	if err != nil {
+		return err
+	}
+
+	if recipient == nil {
+		return fmt.Errorf("recipient not found: %s", n.UserId)
+	}
+
+	data := mailer.TemplateData{
+		RecipientName: recipient.FirstName,
+		Url:           os.Getenv("APP_URL") + n.Path,
+	}
+	if n.Actor != nil {
+		data.ActorName = n.Actor.FirstName
+	}
+	if n.Post != nil {
+		data.PostTitle = n.Post.Title
+	}
+	if n.Order != nil && n.Order.StartDate != nil && n.Order.EndDate != nil {
+		data.StartDate = n.Order.StartDate.Format("2006-01-02")
+		data.EndDate = n.Order.EndDate.Format("2006-01-02")
+	}
+	if n.Message != nil {
+		data.Message = *n.Message
+	}
+	if n.SavedSearch != nil {
+		data.SearchName = n.SavedSearch.Name
+	}
+	for idx := range n.Posts {
+		data.PostTitles = append(data.PostTitles, n.Posts[idx].Title)
+	}
+
+	email, err := mailer.Render(preference.Locale, n.Template, recipient.Email, data)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, *email)
+}
+
+func (s *Service) sendPush(ctx context.Context, n notification, preference *repositories.NotificationPreference) error {
+	if !preference.PushEnabled {
+		return nil
+	}
+
+	devices, err := s.repo.GetDeviceTokens(ctx, n.UserId)
+	if err != nil {
+		return err
+	}
+
+	if len(devices) <= 0 {
+		return nil
+	}
+
+	tokens := make([]string, len(devices))
+	for idx := range devices {
+		tokens[idx] = devices[idx].Token
+	}
+
+	invalidTokens, err := s.push.Send(ctx, tokens, pushMessage(preference.Locale, n))
+	if len(invalidTokens) > 0 {
+		if err := s.repo.DeleteDeviceTokens(ctx, nil, invalidTokens); err != nil {
+			log.Println("Failed to remove invalid device tokens |", err)
+		}
+	}
+
+	return err
+}
+
+func pushMessage(locale string, n notification) push.Message {
+	texts, exists := PUSH_TEXTS[locale]
+	if !exists {
+		texts = PUSH_TEXTS[mailer.DEFAULT_LOCALE]
+	}
+
+	title, body := "Boardhop", ""
+	if n.Post != nil {
+		body = fmt.Sprintf(texts[n.Template], n.Post.Title)
+	}
+	if n.SavedSearch != nil {
+		body = fmt.Sprintf(texts[n.Template], len(n.Posts), n.SavedSearch.Name)
+	}
+	if n.Template == mailer.TEMPLATE_NEW_MESSAGE && n.Actor != nil {
+		title = n.Actor.FirstName
+		if n.Message != nil {
+			body = *n.Message
+		}
+	}
+
+	if runes := []rune(body); len(runes) > MAX_PUSH_BODY_LENGTH {
+		body = string(runes[:MAX_PUSH_BODY_LENGTH-1]) + "…"
+	}
+
+	return push.Message{
+		Title: title,
+		Body:  body,
+		Data: map[string]string{
+			"type": n.Template,
+			"path": n.Path,
+		},
+	}
+}
+
+func emailEnabled(preference *repositories.NotificationPreference, template string) bool {
+	switch template {
+	case mailer.TEMPLATE_NEW_MESSAGE:
+		return preference.EmailMessages
+	case mailer.TEMPLATE_PICKUP_REMINDER:
+		return preference.EmailReminders
+	case mailer.TEMPLATE_SAVED_SEARCH_DIGEST:
+		return preference.EmailDigests
+	case mailer.TEMPLATE_MODERATION_WARNING:
+		return true
+	default:
+		return preference.EmailOrders
+	}
+}
+
+func (s *Service) RunPickupReminders(ctx context.Context) {
+	ticker := time.NewTicker(PICKUP_REMINDER_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sendPickupReminders(ctx); err != nil {
+			log.Println("Failed to send pickup reminders |", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) sendPickupReminders(ctx context.Context) error {
+	orders, err := s.repo.GetPickupReminderOrders(ctx, time.Now().Add(PICKUP_REMINDER_WINDOW))
+	if err != nil {
+		return err
+	}
+
+	for idx := range orders {
+		order := orders[idx]
+		claimed, err := s.repo.MarkOrderReminded(ctx, order.Id)
+		if err != nil {
+			log.Println("Failed to mark order reminded |", order.Id, err)
+			continue
+		}
+
+		if !claimed {
+			continue
+		}
+
+		for _, userId := range []string{order.UserId, order.Post.UserId} {
+			s.notify(notification{
+				UserId:   userId,
+				Template: mailer.TEMPLATE_PICKUP_REMINDER,
+				Post:     &order.Post,
+				Order:    &order,
+				Path:     "/orders/" + order.Id,
+			})
+		}
+	}
+
+	return nil
+}
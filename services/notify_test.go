@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/katakeda/boardhop-api-service-go/mailer"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/push"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendPushRemovesInvalidTokens(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := new(mocks.IRepository)
+	mockRepo.
+		On("GetDeviceTokens", ctx, "owner").
+		Return([]repositories.DeviceToken{{Token: "good"}, {Token: "stale"}}, nil)
+	mockRepo.
+		On("DeleteDeviceTokens", ctx, mock.Anything, []string{"stale"}).
+		Return(nil)
+
+	fake := push.NewFakeSender()
+	fake.InvalidTokens["stale"] = true
+
+	svc, _ := NewService(mockRepo)
+	svc.push = fake
+
+	n := notification{
+		UserId:   "owner",
+		Template: mailer.TEMPLATE_ORDER_ACCEPTED,
+		Post:     &repositories.Post{Title: "Pyzel Ghost 5'10"},
+		Path:     "/orders/order-1",
+	}
+	err := svc.sendPush(ctx, n, &repositories.NotificationPreference{Locale: "en", PushEnabled: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, fake.Sent, 1)
+	assert.Equal(t, []string{"good", "stale"}, fake.Sent[0].Tokens)
+	assert.Equal(t, "Your booking for 'Pyzel Ghost 5'10' was accepted", fake.Sent[0].Message.Body)
+	mockRepo.AssertCalled(t, "DeleteDeviceTokens", ctx, mock.Anything, []string{"stale"})
+}
+
+func TestSendPushRespectsPreference(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+	fake := push.NewFakeSender()
+
+	svc, _ := NewService(mockRepo)
+	svc.push = fake
+
+	err := svc.sendPush(context.Background(), notification{UserId: "owner"}, &repositories.NotificationPreference{PushEnabled: false})
+
+	assert.NoError(t, err)
+	assert.Empty(t, fake.Sent)
+	mockRepo.AssertNotCalled(t, "GetDeviceTokens", mock.Anything, mock.Anything)
+}
+
+func TestPushMessageTruncatesBody(t *testing.T) {
+	message := strings.Repeat("あ", MAX_MESSAGE_LENGTH)
+	n := notification{
+		Template: mailer.TEMPLATE_NEW_MESSAGE,
+		Actor:    &repositories.User{FirstName: "Taro"},
+		Message:  &message,
+	}
+
+	body := []rune(pushMessage("ja", n).Body)
+	assert.Len(t, body, MAX_PUSH_BODY_LENGTH)
+	assert.Equal(t, '…', body[len(body)-1])
+}
+
+func TestSendPickupRemindersSkipsClaimedOrders(t *testing.T) {
+	ctx := context.Background()
+	notified := make(chan string, 4)
+
+	mockRepo := new(mocks.IRepository)
+	mockRepo.
+		On("GetPickupReminderOrders", ctx, mock.Anything).
+		Return([]repositories.Order{
+			{Id: "order-1", UserId: "renter-1", Post: repositories.Post{UserId: "owner-1"}},
+			{Id: "order-2", UserId: "renter-2", Post: repositories.Post{UserId: "owner-2"}},
+		}, nil)
+	mockRepo.On("MarkOrderReminded", ctx, "order-1").Return(false, nil)
+	mockRepo.On("MarkOrderReminded", ctx, "order-2").Return(true, nil)
+	mockRepo.
+		On("GetNotificationPreference", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { notified <- args.String(1) }).
+		Return(nil, errors.New("stop"))
+
+	svc, _ := NewService(mockRepo)
+
+	assert.NoError(t, svc.sendPickupReminders(ctx))
+
+	recipients := []string{}
+	for len(recipients) < 2 {
+		select {
+		case userId := <-notified:
+			recipients = append(recipients, userId)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for reminders")
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"renter-2", "owner-2"}, recipients)
+	select {
+	case userId := <-notified:
+		t.Fatalf("unexpected reminder for %s", userId)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mailer"
 	"github.com/katakeda/boardhop-api-service-go/repositories"
 )
 
@@ -21,6 +23,10 @@ func (s *Service) CreateOrder(c *gin.Context) {
 	s.createOrder(c)
 }
 
+func (s *Service) UpdateOrder(c *gin.Context) {
+	s.updateOrder(c)
+}
+
 func (s *Service) getOrders(c *gin.Context) (err error) {
 	defer func() {
 		if err != nil {
@@ -45,6 +51,13 @@ func (s *Service) getOrders(c *gin.Context) (err error) {
 		return
 	}
 
+	for idx := range orders {
+		showContact := ACCEPTED_ORDER_STATUSES[orders[idx].Status]
+		if err := s.setPostVisibility(c, &orders[idx].Post, showContact); err != nil {
+			return fmt.Errorf("failed to set post visibility | %w", err)
+		}
+	}
+
 	c.JSON(http.StatusOK, orders)
 
 	return nil
@@ -58,12 +71,32 @@ func (s *Service) getOrder(c *gin.Context) (err error) {
 		}
 	}()
 
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
 	id := c.Param("id")
 	order, err := s.repo.GetOrder(c, id)
 	if err != nil {
 		return fmt.Errorf("failed to get order | %w", err)
 	}
 
+	if order == nil {
+		c.JSON(http.StatusNotFound, "Order not found")
+		return nil
+	}
+
+	if !isOrderParty(order, user) {
+		c.JSON(http.StatusForbidden, "Not allowed to view this order")
+		return nil
+	}
+
+	showContact := order.Post.UserId == user.Id || ACCEPTED_ORDER_STATUSES[order.Status]
+	if err := s.setPostVisibility(c, &order.Post, showContact); err != nil {
+		return fmt.Errorf("failed to set post visibility | %w", err)
+	}
+
 	c.JSON(http.StatusOK, order)
 
 	return nil
@@ -90,7 +123,30 @@ func (s *Service) createOrder(c *gin.Context) (err error) {
 		return fmt.Errorf("failed to authorize user | %w", err)
 	}
 
+	post, err := s.repo.GetPost(ctx, payload.PostId)
+	if err != nil {
+		return fmt.Errorf("failed to get post | %w", err)
+	}
+
+	if post != nil {
+		// Lock both parties against a concurrent deleteUser, then re-read the post it may have deleted.
+		if err := s.repo.LockUsers(ctx, []string{user.Id, post.UserId}); err != nil {
+			return fmt.Errorf("failed to lock users | %w", err)
+		}
+
+		if post, err = s.repo.GetPost(ctx, payload.PostId); err != nil {
+			return fmt.Errorf("failed to get post | %w", err)
+		}
+	}
+
+	if post == nil || post.DeletedAt != nil || post.HiddenAt != nil || post.UserSuspendedAt != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusNotFound, "Post not found")
+		return nil
+	}
+
 	payload.UserId = user.Id
+	payload.Status = "pending"
 
 	order, err := s.repo.CreateOrder(ctx, payload)
 	if err != nil {
@@ -98,14 +154,154 @@ func (s *Service) createOrder(c *gin.Context) (err error) {
 	}
 
 	if payload.Message != nil {
-		message, err := s.repo.CreateMessage(ctx, repositories.CreateMessagePayload{UserId: user.Id, OrderId: &order.Id, Message: payload.Message})
+		text, matches := s.screenMessage(payload.Message, true)
+		message, err := s.repo.CreateMessage(ctx, repositories.CreateMessagePayload{UserId: user.Id, OrderId: &order.Id, Message: text})
 		if err != nil {
 			return fmt.Errorf("failed to insert order message | %w", err)
 		}
+		if len(matches) > 0 {
+			flag := repositories.CreateMessageFlagPayload{MessageId: message.Id, Reason: "filtered_words", Matches: matches}
+			if err := s.repo.CreateMessageFlag(ctx, flag); err != nil {
+				return fmt.Errorf("failed to flag order message | %w", err)
+			}
+		}
 		order.Messages = []repositories.Message{*message}
 	}
 
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
 	c.JSON(http.StatusOK, order)
 
-	return s.repo.CommitTxn(ctx)
+	s.notify(notification{
+		UserId:   post.UserId,
+		Template: mailer.TEMPLATE_ORDER_REQUESTED,
+		Actor:    user,
+		Post:     post,
+		Order:    order,
+		Path:     "/orders/" + order.Id,
+	})
+
+	return nil
+}
+
+func (s *Service) updateOrder(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update order |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating order")
+		}
+	}()
+
+	payload := repositories.UpdateOrderPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, "Status is required")
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	id := c.Param("id")
+	order, err := s.repo.GetOrder(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get order | %w", err)
+	}
+
+	if order == nil {
+		c.JSON(http.StatusNotFound, "Order not found")
+		return nil
+	}
+
+	if !isOrderParty(order, user) {
+		c.JSON(http.StatusForbidden, "Not allowed to update this order")
+		return nil
+	}
+
+	if !canTransitionOrder(order, user, payload.Status) {
+		c.JSON(http.StatusConflict, fmt.Sprintf("Order can't be changed from %s to %s", order.Status, payload.Status))
+		return nil
+	}
+
+	updated, err := s.repo.UpdateOrderStatus(c, order.Id, order.Status, payload.Status)
+	if err != nil {
+		return fmt.Errorf("failed to update order status | %w", err)
+	}
+
+	if !updated {
+		c.JSON(http.StatusConflict, "Order was changed by another request")
+		return nil
+	}
+
+	order.Status = payload.Status
+	if order.Status == "complete" {
+		now := time.Now()
+		order.CompletedAt = &now
+	}
+
+	c.JSON(http.StatusOK, order)
+
+	switch payload.Status {
+	case "accepted":
+		s.notify(notification{
+			UserId:   order.UserId,
+			Template: mailer.TEMPLATE_ORDER_ACCEPTED,
+			Actor:    user,
+			Post:     &order.Post,
+			Order:    order,
+			Path:     "/orders/" + order.Id,
+		})
+	case "canceled":
+		recipientId := order.UserId
+		if user.Id == order.UserId {
+			recipientId = order.Post.UserId
+		}
+		s.notify(notification{
+			UserId:   recipientId,
+			Template: mailer.TEMPLATE_ORDER_CANCELED,
+			Actor:    user,
+			Post:     &order.Post,
+			Order:    order,
+			Path:     "/orders/" + order.Id,
+		})
+	case "complete":
+		s.notify(notification{
+			UserId:   order.UserId,
+			Template: mailer.TEMPLATE_REVIEW_REQUEST,
+			Actor:    user,
+			Post:     &order.Post,
+			Order:    order,
+			Path:     "/orders/" + order.Id,
+		})
+		if renter, err := s.repo.GetUserById(c, order.UserId); err == nil && renter != nil {
+			s.notify(notification{
+				UserId:   order.Post.UserId,
+				Template: mailer.TEMPLATE_REVIEW_REQUEST,
+				Actor:    renter,
+				Post:     &order.Post,
+				Order:    order,
+				Path:     "/orders/" + order.Id,
+			})
+		}
+	}
+
+	return nil
+}
+
+func canTransitionOrder(order *repositories.Order, user *repositories.User, status string) bool {
+	isOwner := order.Post.UserId == user.Id
+
+	switch status {
+	case "accepted":
+		return isOwner && order.Status == "pending"
+	case "canceled":
+		return order.Status == "pending" || order.Status == "accepted"
+	case "complete":
+		return isOwner && order.Status == "accepted"
+	default:
+		return false
+	}
 }
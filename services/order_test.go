@@ -0,0 +1,41 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateOrderStaleStatus(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "owner-uid").
+		Return(&repositories.User{Id: "owner"}, nil)
+	mockRepo.
+		On("GetOrder", mock.Anything, "order-1").
+		Return(&repositories.Order{Id: "order-1", UserId: "renter", Status: "pending", Post: repositories.Post{UserId: "owner"}}, nil)
+	mockRepo.
+		On("UpdateOrderStatus", mock.Anything, "order-1", "pending", "accepted").
+		Return(false, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.PATCH("/orders/:id", func(c *gin.Context) { c.Set("googleAuthId", "owner-uid") }, svc.UpdateOrder)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/orders/order-1", strings.NewReader(`{"status":"accepted"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockRepo.AssertNotCalled(t, "GetNotificationPreference", mock.Anything, mock.Anything)
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/katakeda/boardhop-api-service-go/repositories"
@@ -12,7 +15,21 @@ import (
 func (s *Service) GetPosts(c *gin.Context) {
 	params := c.Request.URL.Query()
 
-	posts, err := s.repo.GetPosts(c, params)
+	if _, err := repositories.ParsePostsFilter(params); err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if msg, err := s.validatePostsCategory(c, params); err != nil {
+		log.Println("Failed to validate category", err)
+		c.JSON(http.StatusInternalServerError, "Something went wrong while getting posts")
+		return
+	} else if msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return
+	}
+
+	posts, err := s.repo.GetPosts(localize(c), params)
 	if err != nil {
 		log.Println("Failed to get posts", err)
 		c.JSON(http.StatusInternalServerError, "Something went wrong while getting posts")
@@ -25,6 +42,12 @@ func (s *Service) GetPosts(c *gin.Context) {
 		return
 	}
 
+	if err := s.setFavorited(c, posts, s.getViewer(c)); err != nil {
+		log.Println("Failed to set favorited posts", err)
+		c.JSON(http.StatusInternalServerError, "Something went wrong while getting posts")
+		return
+	}
+
 	c.JSON(http.StatusOK, posts)
 }
 
@@ -48,6 +71,24 @@ func (s *Service) GetCategories(c *gin.Context) {
 	s.getCategories(c)
 }
 
+func (s *Service) validatePostsCategory(c *gin.Context, params url.Values) (string, error) {
+	category := repositories.PostsCategory(params)
+	if category == "" {
+		return "", nil
+	}
+
+	exists, err := s.repo.CategoryExists(c, category, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to check category | %w", err)
+	}
+
+	if !exists {
+		return fmt.Sprintf("unknown category: %s", category), nil
+	}
+
+	return "", nil
+}
+
 func (s *Service) getPost(c *gin.Context) (err error) {
 	defer func() {
 		if err != nil {
@@ -58,16 +99,38 @@ func (s *Service) getPost(c *gin.Context) (err error) {
 
 	id := c.Param("id")
 
-	post, err := s.repo.GetPost(c, id)
+	post, err := s.repo.GetPost(localize(c), id)
 	if err != nil {
 		return fmt.Errorf("failed to get post | %w", err)
 	}
 
-	if post == nil {
+	if post == nil || post.DeletedAt != nil {
+		c.JSON(http.StatusNotFound, "Post not found")
+		return nil
+	}
+
+	viewer := s.getViewer(c)
+
+	if (post.HiddenAt != nil || post.UserSuspendedAt != nil) && !canViewModerated(post, viewer) {
 		c.JSON(http.StatusNotFound, "Post not found")
 		return nil
 	}
 
+	showContact, err := s.canViewContact(c, post, viewer)
+	if err != nil {
+		return fmt.Errorf("failed to check contact visibility | %w", err)
+	}
+
+	posts := []repositories.Post{*post}
+	if err := s.setFavorited(c, posts, viewer); err != nil {
+		return fmt.Errorf("failed to set favorited post | %w", err)
+	}
+	post.IsFavorited = posts[0].IsFavorited
+
+	if err := s.setPostVisibility(c, post, showContact); err != nil {
+		return fmt.Errorf("failed to set post visibility | %w", err)
+	}
+
 	c.JSON(http.StatusOK, post)
 
 	return nil
@@ -93,6 +156,16 @@ func (s *Service) createPost(c *gin.Context) (err error) {
 
 	payload.Data.UserId = user.Id
 
+	msg, err := s.validatePostSpecs(c, payload.Data.Specs, payload.Relationships.CategoryIds)
+	if err != nil {
+		return fmt.Errorf("failed to validate specs | %w", err)
+	}
+
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
 	ctx, err := s.repo.BeginTxn(c)
 	if err != nil {
 		return fmt.Errorf("failed to begin db txn | %w", err)
@@ -172,6 +245,32 @@ func (s *Service) updatePost(c *gin.Context) (err error) {
 		return fmt.Errorf("unauthorized request")
 	}
 
+	if payload.Data.Specs != nil || len(payload.Relationships.CategoryIds) > 0 {
+		specs := payload.Data.Specs
+		if specs == nil {
+			specs = post.Specs
+		}
+
+		categoryIds := payload.Relationships.CategoryIds
+		if len(categoryIds) <= 0 {
+			for _, category := range post.Categories {
+				if category.Id != nil {
+					categoryIds = append(categoryIds, strconv.Itoa(*category.Id))
+				}
+			}
+		}
+
+		msg, err := s.validatePostSpecs(c, specs, categoryIds)
+		if err != nil {
+			return fmt.Errorf("failed to validate specs | %w", err)
+		}
+
+		if msg != "" {
+			c.JSON(http.StatusBadRequest, msg)
+			return nil
+		}
+	}
+
 	ctx, err := s.repo.BeginTxn(c)
 	if err != nil {
 		return fmt.Errorf("failed to begin db txn | %w", err)
@@ -256,7 +355,22 @@ func (s *Service) getTags(c *gin.Context) (err error) {
 
 	params := c.Request.URL.Query()
 
-	tags, err := s.repo.GetTags(c, params)
+	filter := repositories.GetTagsFilter{}
+	if categoryIds := params.Get("categoryIds"); categoryIds != "" {
+		for _, categoryId := range strings.Split(categoryIds, ",") {
+			id, err := strconv.Atoi(categoryId)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, "Invalid category id")
+				return nil
+			}
+			filter.CategoryIds = append(filter.CategoryIds, id)
+		}
+	}
+	if categories := params.Get("type"); categories != "" {
+		filter.CategoryValues = strings.Split(categories, ",")
+	}
+
+	tags, err := s.repo.GetTags(localize(c), filter)
 	if err != nil {
 		return fmt.Errorf("failed to get tags | %w", err)
 	}
@@ -279,7 +393,25 @@ func (s *Service) getCategories(c *gin.Context) (err error) {
 		}
 	}()
 
-	categories, err := s.repo.GetCategories(c)
+	ctx := localize(c)
+
+	format := c.Query("format")
+	if format != "" && format != "flat" && format != "tree" {
+		c.JSON(http.StatusBadRequest, "Format must be one of flat or tree")
+		return nil
+	}
+
+	if format == "tree" {
+		categories, err := s.repo.GetCategoriesWithPostCount(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get categories | %w", err)
+		}
+
+		c.JSON(http.StatusOK, buildCategoryTree(categories))
+		return nil
+	}
+
+	categories, err := s.repo.GetCategories(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get categories | %w", err)
 	}
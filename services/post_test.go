@@ -2,11 +2,14 @@ package services
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/katakeda/boardhop-api-service-go/mocks"
 	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -24,3 +27,25 @@ func TestGetPosts(t *testing.T) {
 	router := gin.Default()
 	router.GET("/posts", svc.GetPosts)
 }
+
+func TestGetPostHidesContactFromAnonymous(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	lastName := "Yamada"
+	mockRepo.
+		On("GetPost", mock.Anything, "post-1").
+		Return(&repositories.Post{Id: "post-1", UserId: "owner", LastName: &lastName}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.GET("/posts/:id", svc.GetPost)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts/post-1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"email"`)
+	assert.Contains(t, w.Body.String(), `"lastName":"Y."`)
+	mockRepo.AssertNotCalled(t, "GetUserById", mock.Anything, mock.Anything)
+}
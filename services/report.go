@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+const (
+	MAX_REPORT_DETAILS_LENGTH = 1000
+)
+
+func (s *Service) CreatePostReport(c *gin.Context) {
+	s.createPostReport(c)
+}
+
+func (s *Service) createPostReport(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to create post report |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while reporting post")
+		}
+	}()
+
+	payload := repositories.CreatePostReportPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil || !repositories.REPORT_REASONS[payload.Reason] {
+		c.JSON(http.StatusBadRequest, "Reason must be one of spam, prohibited_item, misleading, offensive, scam or other")
+		return nil
+	}
+
+	if payload.Details != nil {
+		details := strings.TrimSpace(*payload.Details)
+		if utf8.RuneCountInString(details) > MAX_REPORT_DETAILS_LENGTH {
+			c.JSON(http.StatusBadRequest, fmt.Sprintf("Details must be %d characters or less", MAX_REPORT_DETAILS_LENGTH))
+			return nil
+		}
+		payload.Details = &details
+		if details == "" {
+			payload.Details = nil
+		}
+	}
+
+	if payload.Reason == "other" && payload.Details == nil {
+		c.JSON(http.StatusBadRequest, "Details are required when reason is other")
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	post, err := s.repo.GetPost(c, c.Param("id"))
+	if err != nil {
+		return fmt.Errorf("failed to get post | %w", err)
+	}
+
+	if post == nil || post.DeletedAt != nil {
+		c.JSON(http.StatusNotFound, "Post not found")
+		return nil
+	}
+
+	if post.UserId == user.Id {
+		c.JSON(http.StatusBadRequest, "Cannot report your own post")
+		return nil
+	}
+
+	payload.PostId = post.Id
+	payload.ReporterId = user.Id
+
+	report, err := s.repo.CreatePostReport(c, payload)
+	if err != nil {
+		return fmt.Errorf("failed to create post report | %w", err)
+	}
+
+	if report == nil {
+		c.JSON(http.StatusConflict, "You have already reported this post")
+		return nil
+	}
+
+	c.JSON(http.StatusOK, report)
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePostReportRejectsOwnPost(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "owner-uid").
+		Return(&repositories.User{Id: "owner"}, nil)
+	mockRepo.
+		On("GetPost", mock.Anything, "post-1").
+		Return(&repositories.Post{Id: "post-1", UserId: "owner"}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.POST("/posts/:id/report", func(c *gin.Context) { c.Set("googleAuthId", "owner-uid") }, svc.CreatePostReport)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/posts/post-1/report", strings.NewReader(`{"reason":"spam"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRepo.AssertNotCalled(t, "CreatePostReport", mock.Anything, mock.Anything)
+}
+
+func TestGetPostHidesModeratedPost(t *testing.T) {
+	hiddenAt := time.Now()
+
+	mockRepo := new(mocks.IRepository)
+	mockRepo.
+		On("GetPost", mock.Anything, "post-1").
+		Return(&repositories.Post{Id: "post-1", UserId: "owner", HiddenAt: &hiddenAt}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.GET("/posts/:id", svc.GetPost)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts/post-1", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreateOrderRejectsModeratedPost(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	hiddenAt := time.Now()
+	mockRepo.On("BeginTxn", mock.Anything).Return(context.Background(), nil)
+	mockRepo.On("RollbackTxn", mock.Anything).Return(nil)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "renter-uid").
+		Return(&repositories.User{Id: "renter"}, nil)
+	mockRepo.
+		On("GetPost", mock.Anything, "post-1").
+		Return(&repositories.Post{Id: "post-1", UserId: "owner", HiddenAt: &hiddenAt}, nil)
+	mockRepo.
+		On("LockUsers", mock.Anything, []string{"renter", "owner"}).
+		Return(nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.POST("/orders", func(c *gin.Context) { c.Set("googleAuthId", "renter-uid") }, svc.CreateOrder)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"postId":"post-1","quantity":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mailer"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+const (
+	MAX_REVIEW_COMMENT_LENGTH = 1000
+)
+
+func (s *Service) GetPostReviews(c *gin.Context) {
+	s.getPostReviews(c)
+}
+
+func (s *Service) GetOrderReviews(c *gin.Context) {
+	s.getOrderReviews(c)
+}
+
+func (s *Service) CreateReview(c *gin.Context) {
+	s.createReview(c)
+}
+
+func (s *Service) getPostReviews(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get post reviews |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting reviews")
+		}
+	}()
+
+	id := c.Param("id")
+	role := "renter"
+
+	reviews, err := s.repo.GetReviews(c, repositories.GetReviewsFilter{PostId: &id, Role: &role, VisibleOnly: true}, c.Request.URL.Query())
+	if err != nil {
+		return fmt.Errorf("failed to get reviews | %w", err)
+	}
+
+	if reviews == nil {
+		reviews = []repositories.Review{}
+	}
+
+	c.JSON(http.StatusOK, reviews)
+
+	return nil
+}
+
+func (s *Service) getOrderReviews(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get order reviews |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting reviews")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	order, err := s.repo.GetOrder(c, c.Param("id"))
+	if err != nil {
+		return fmt.Errorf("failed to get order | %w", err)
+	}
+
+	if order == nil {
+		c.JSON(http.StatusNotFound, "Order not found")
+		return nil
+	}
+
+	if !isOrderParty(order, user) {
+		c.JSON(http.StatusForbidden, "Not allowed to view this order")
+		return nil
+	}
+
+	reviews, err := s.repo.GetReviews(c, repositories.GetReviewsFilter{OrderId: &order.Id}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get reviews | %w", err)
+	}
+
+	visible := []repositories.Review{}
+	for idx := range reviews {
+		if reviews[idx].Visible || reviews[idx].ReviewerId == user.Id {
+			visible = append(visible, reviews[idx])
+		}
+	}
+
+	c.JSON(http.StatusOK, visible)
+
+	return nil
+}
+
+func (s *Service) createReview(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to create review |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while creating review")
+		}
+	}()
+
+	payload := repositories.CreateReviewPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, "Rating is required")
+		return nil
+	}
+
+	if msg := validateReviewPayload(payload); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	order, err := s.repo.GetOrder(c, c.Param("id"))
+	if err != nil {
+		return fmt.Errorf("failed to get order | %w", err)
+	}
+
+	if order == nil {
+		c.JSON(http.StatusNotFound, "Order not found")
+		return nil
+	}
+
+	if !isOrderParty(order, user) {
+		c.JSON(http.StatusForbidden, "Not allowed to review this order")
+		return nil
+	}
+
+	if order.Status != "complete" || order.CompletedAt == nil {
+		c.JSON(http.StatusConflict, "Only completed orders can be reviewed")
+		return nil
+	}
+
+	if time.Since(*order.CompletedAt) > repositories.REVIEW_WINDOW {
+		c.JSON(http.StatusConflict, "The review window for this order has closed")
+		return nil
+	}
+
+	payload.OrderId, payload.PostId, payload.ReviewerId = order.Id, order.PostId, user.Id
+	if order.UserId == user.Id {
+		payload.Role, payload.RevieweeId = "renter", order.Post.UserId
+	} else {
+		payload.Role, payload.RevieweeId, payload.PostRating = "owner", order.UserId, nil
+	}
+
+	review, err := s.repo.CreateReview(c, payload)
+	if err != nil {
+		return fmt.Errorf("failed to insert review | %w", err)
+	}
+
+	if review == nil {
+		c.JSON(http.StatusConflict, "You have already reviewed this order")
+		return nil
+	}
+
+	c.JSON(http.StatusOK, review)
+
+	s.notify(notification{
+		UserId:   review.RevieweeId,
+		Template: mailer.TEMPLATE_REVIEW_RECEIVED,
+		Actor:    user,
+		Post:     &order.Post,
+		Order:    order,
+		Path:     "/orders/" + order.Id,
+	})
+
+	return nil
+}
+
+func validateReviewPayload(payload repositories.CreateReviewPayload) string {
+	if payload.Rating < 1 || payload.Rating > 5 {
+		return "Rating must be between 1 and 5"
+	}
+
+	if payload.PostRating != nil && (*payload.PostRating < 1 || *payload.PostRating > 5) {
+		return "Post rating must be between 1 and 5"
+	}
+
+	if payload.Comment != nil && utf8.RuneCountInString(*payload.Comment) > MAX_REVIEW_COMMENT_LENGTH {
+		return fmt.Sprintf("Comment must be %d characters or less", MAX_REVIEW_COMMENT_LENGTH)
+	}
+
+	return ""
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateReviewAfterWindowCloses(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	completedAt := time.Now().Add(-repositories.REVIEW_WINDOW - time.Hour)
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "renter-uid").
+		Return(&repositories.User{Id: "renter"}, nil)
+	mockRepo.
+		On("GetOrder", mock.Anything, "order-1").
+		Return(&repositories.Order{
+			Id:          "order-1",
+			UserId:      "renter",
+			Status:      "complete",
+			CompletedAt: &completedAt,
+			Post:        repositories.Post{UserId: "owner"},
+		}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.POST("/orders/:id/reviews", func(c *gin.Context) { c.Set("googleAuthId", "renter-uid") }, svc.CreateReview)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders/order-1/reviews", strings.NewReader(`{"rating":5,"postRating":4}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockRepo.AssertNotCalled(t, "CreateReview", mock.Anything, mock.Anything)
+}
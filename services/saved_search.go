@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mailer"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+const (
+	MAX_SAVED_SEARCHES          = 20
+	MAX_SAVED_SEARCH_NAME       = 100
+	SAVED_SEARCH_INTERVAL       = time.Hour
+	SAVED_SEARCH_DIGEST_PERIOD  = 24 * time.Hour
+	SAVED_SEARCH_DIGEST_MAX_LEN = 10
+)
+
+func (s *Service) GetSavedSearches(c *gin.Context) {
+	s.getSavedSearches(c)
+}
+
+func (s *Service) CreateSavedSearch(c *gin.Context) {
+	s.createSavedSearch(c)
+}
+
+func (s *Service) DeleteSavedSearch(c *gin.Context) {
+	s.deleteSavedSearch(c)
+}
+
+func (s *Service) getSavedSearches(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get saved searches |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting saved searches")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	searches, err := s.repo.GetSavedSearches(c, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get saved searches | %w", err)
+	}
+
+	if searches == nil {
+		searches = []repositories.SavedSearch{}
+	}
+
+	c.JSON(http.StatusOK, searches)
+
+	return nil
+}
+
+func (s *Service) createSavedSearch(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to create saved search |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while creating saved search")
+		}
+	}()
+
+	payload := repositories.CreateSavedSearchPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, "Name and query are required")
+		return nil
+	}
+
+	payload.Name = strings.TrimSpace(payload.Name)
+	if payload.Name == "" || utf8.RuneCountInString(payload.Name) > MAX_SAVED_SEARCH_NAME {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Name must be between 1 and %d characters", MAX_SAVED_SEARCH_NAME))
+		return nil
+	}
+
+	query, err := normalizeSavedSearchQuery(payload.Query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return nil
+	}
+
+	params, _ := url.ParseQuery(query)
+	msg, err := s.validatePostsCategory(c, params)
+	if err != nil {
+		return fmt.Errorf("failed to validate category | %w", err)
+	}
+
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	payload.Query = query
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	searches, err := s.repo.GetSavedSearches(c, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get saved searches | %w", err)
+	}
+
+	if len(searches) >= MAX_SAVED_SEARCHES {
+		c.JSON(http.StatusConflict, fmt.Sprintf("No more than %d saved searches are allowed", MAX_SAVED_SEARCHES))
+		return nil
+	}
+
+	payload.UserId = user.Id
+
+	search, err := s.repo.CreateSavedSearch(c, payload)
+	if err != nil {
+		return fmt.Errorf("failed to insert saved search | %w", err)
+	}
+
+	c.JSON(http.StatusOK, search)
+
+	return nil
+}
+
+func (s *Service) deleteSavedSearch(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to delete saved search |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while deleting saved search")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid saved search id")
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	if err := s.repo.DeleteSavedSearch(c, user.Id, id); err != nil {
+		return fmt.Errorf("failed to delete saved search | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
+
+func normalizeSavedSearchQuery(query string) (string, error) {
+	params, err := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if err != nil {
+		return "", fmt.Errorf("query is not a valid query string")
+	}
+
+	normalized := url.Values{}
+	for _, key := range repositories.POST_FILTER_KEYS {
+		if value := params.Get(key); value != "" {
+			normalized.Set(key, value)
+		}
+	}
+	for key := range params {
+		if _, ok := repositories.SpecRangeKey(key); ok && params.Get(key) != "" {
+			normalized.Set(key, params.Get(key))
+		}
+	}
+
+	if len(normalized) <= 0 {
+		return "", fmt.Errorf("query must include at least one filter")
+	}
+
+	if _, err := repositories.ParsePostsFilter(normalized); err != nil {
+		return "", err
+	}
+
+	return normalized.Encode(), nil
+}
+
+func (s *Service) RunSavedSearchDigests(ctx context.Context) {
+	ticker := time.NewTicker(SAVED_SEARCH_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sendSavedSearchDigests(ctx); err != nil {
+			log.Println("Failed to send saved search digests |", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) sendSavedSearchDigests(ctx context.Context) error {
+	now := time.Now()
+
+	searches, err := s.repo.GetDueSavedSearches(ctx, now.Add(-SAVED_SEARCH_DIGEST_PERIOD))
+	if err != nil {
+		return err
+	}
+
+	for idx := range searches {
+		search := searches[idx]
+
+		params, err := url.ParseQuery(search.Query)
+		if err != nil {
+			log.Println("Failed to parse saved search query |", search.Id, err)
+			continue
+		}
+
+		params.Set("since", search.LastNotifiedAt.Format(time.RFC3339))
+		params.Set("sort", "newest")
+		params.Set("l", strconv.Itoa(SAVED_SEARCH_DIGEST_MAX_LEN))
+
+		posts, err := s.repo.GetPosts(ctx, params)
+		if err != nil {
+			log.Println("Failed to get saved search posts |", search.Id, err)
+			continue
+		}
+
+		claimed, err := s.repo.MarkSavedSearchNotified(ctx, search.Id, *search.LastNotifiedAt, now)
+		if err != nil {
+			log.Println("Failed to mark saved search notified |", search.Id, err)
+			continue
+		}
+
+		if !claimed || len(posts) <= 0 {
+			continue
+		}
+
+		s.notify(notification{
+			UserId:      search.UserId,
+			Template:    mailer.TEMPLATE_SAVED_SEARCH_DIGEST,
+			SavedSearch: &search,
+			Posts:       posts,
+			Path:        "/posts?" + search.Query,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNormalizeSavedSearchQuery(t *testing.T) {
+	query, err := normalizeSavedSearchQuery("?priceMax=5000&cats=surfboard&p=2&sort=popular&lat=35.3&lng=139.5&radius=20")
+	assert.NoError(t, err)
+	assert.Equal(t, "cats=surfboard&lat=35.3&lng=139.5&priceMax=5000&radius=20", query)
+
+	for _, invalid := range []string{
+		"p=1",
+		"priceMin=abc",
+		"priceMin=500&priceMax=100",
+		"lat=35.3&lng=139.5",
+		"lat=35.3&lng=139.5&radius=1000",
+	} {
+		_, err := normalizeSavedSearchQuery(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCreateSavedSearchRejectsUnknownCategory(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("CategoryExists", mock.Anything, "unknown", (*int)(nil)).
+		Return(false, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.POST("/user/saved-searches", func(c *gin.Context) { c.Set("googleAuthId", "user-uid") }, svc.CreateSavedSearch)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/user/saved-searches", strings.NewReader(`{"name":"Boards","query":"cats=unknown"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown category: unknown")
+	mockRepo.AssertNotCalled(t, "CreateSavedSearch", mock.Anything, mock.Anything)
+}
+
+func TestSendSavedSearchDigestsOnlyNotifiesClaimed(t *testing.T) {
+	ctx := context.Background()
+	last := time.Now().Add(-2 * SAVED_SEARCH_DIGEST_PERIOD)
+	notified := make(chan string, 3)
+
+	mockRepo := new(mocks.IRepository)
+	mockRepo.
+		On("GetDueSavedSearches", ctx, mock.Anything).
+		Return([]repositories.SavedSearch{
+			{Id: 1, UserId: "user-1", Query: "cats=surfboard", LastNotifiedAt: &last},
+			{Id: 2, UserId: "user-2", Query: "cats=surfboard", LastNotifiedAt: &last},
+			{Id: 3, UserId: "user-3", Query: "cats=surfboard", LastNotifiedAt: &last},
+		}, nil)
+	mockRepo.
+		On("GetPosts", ctx, mock.Anything).
+		Return([]repositories.Post{{Title: "Pyzel Ghost"}}, nil)
+	mockRepo.On("MarkSavedSearchNotified", ctx, 1, last, mock.Anything).Return(false, errors.New("boom"))
+	mockRepo.On("MarkSavedSearchNotified", ctx, 2, last, mock.Anything).Return(false, nil)
+	mockRepo.On("MarkSavedSearchNotified", ctx, 3, last, mock.Anything).Return(true, nil)
+	mockRepo.
+		On("GetNotificationPreference", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { notified <- args.String(1) }).
+		Return(nil, errors.New("stop"))
+
+	svc, _ := NewService(mockRepo)
+
+	assert.NoError(t, svc.sendSavedSearchDigests(ctx))
+
+	select {
+	case userId := <-notified:
+		assert.Equal(t, "user-3", userId)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for digest")
+	}
+	select {
+	case userId := <-notified:
+		t.Fatalf("unexpected digest for %s", userId)
+	case <-time.After(50 * time.Millisecond):
+	}
+	mockRepo.AssertNumberOfCalls(t, "MarkSavedSearchNotified", 3)
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+const (
+	MAX_SPEC_TEXT_LENGTH = 255
+)
+
+func (s *Service) GetCategoryAttributes(c *gin.Context) {
+	s.getCategoryAttributes(c)
+}
+
+func (s *Service) getCategoryAttributes(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get category attributes |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting category attributes")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid category id")
+		return nil
+	}
+
+	ctx := localize(c)
+
+	category, err := s.repo.GetCategory(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get category | %w", err)
+	}
+
+	if category == nil || category.RetiredAt != nil {
+		c.JSON(http.StatusNotFound, "Category not found")
+		return nil
+	}
+
+	attributes, err := s.repo.GetCategoryAttributes(ctx, []int{id})
+	if err != nil {
+		return fmt.Errorf("failed to get category attributes | %w", err)
+	}
+
+	if attributes == nil {
+		attributes = []repositories.CategoryAttribute{}
+	}
+
+	c.JSON(http.StatusOK, attributes)
+
+	return nil
+}
+
+func (s *Service) validatePostSpecs(ctx context.Context, specs map[string]interface{}, categoryIds []string) (string, error) {
+	ids := []int{}
+	for _, categoryId := range categoryIds {
+		id, err := strconv.Atoi(categoryId)
+		if err != nil {
+			return "Invalid category id", nil
+		}
+		ids = append(ids, id)
+	}
+
+	attributes := []repositories.CategoryAttribute{}
+	if len(ids) > 0 {
+		var err error
+		if attributes, err = s.repo.GetCategoryAttributes(ctx, ids); err != nil {
+			return "", fmt.Errorf("failed to get category attributes | %w", err)
+		}
+	}
+
+	return validateSpecs(specs, attributes), nil
+}
+
+func validateSpecs(specs map[string]interface{}, attributes []repositories.CategoryAttribute) string {
+	byKey := map[string]repositories.CategoryAttribute{}
+	for _, attribute := range attributes {
+		byKey[attribute.Key] = attribute
+	}
+
+	keys := []string{}
+	for key := range specs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		attribute, exists := byKey[key]
+		if !exists {
+			return fmt.Sprintf("Unknown spec: %s", key)
+		}
+
+		switch attribute.Type {
+		case repositories.ATTRIBUTE_NUMBER:
+			value, ok := specs[key].(float64)
+			if !ok {
+				return fmt.Sprintf("%s must be a number", key)
+			}
+			if attribute.Min != nil && value < *attribute.Min || attribute.Max != nil && value > *attribute.Max {
+				return fmt.Sprintf("%s must be between %s and %s", key, formatBound(attribute.Min), formatBound(attribute.Max))
+			}
+		case repositories.ATTRIBUTE_TEXT:
+			value, ok := specs[key].(string)
+			if !ok || strings.TrimSpace(value) == "" {
+				return fmt.Sprintf("%s must be a non-empty string", key)
+			}
+			if utf8.RuneCountInString(value) > MAX_SPEC_TEXT_LENGTH {
+				return fmt.Sprintf("%s must be %d characters or fewer", key, MAX_SPEC_TEXT_LENGTH)
+			}
+		case repositories.ATTRIBUTE_ENUM:
+			value, _ := specs[key].(string)
+			if !containsString(attribute.Options, value) {
+				return fmt.Sprintf("%s must be one of %s", key, strings.Join(attribute.Options, ", "))
+			}
+		}
+	}
+
+	for _, attribute := range attributes {
+		if _, exists := specs[attribute.Key]; attribute.Required && !exists {
+			return fmt.Sprintf("%s is required", attribute.Key)
+		}
+	}
+
+	return ""
+}
+
+func formatBound(bound *float64) string {
+	if bound == nil {
+		return "any"
+	}
+
+	return strconv.FormatFloat(*bound, 'f', -1, 64)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSpecs(t *testing.T) {
+	min, max := 10.0, 120.0
+	attributes := []repositories.CategoryAttribute{
+		{Key: "volume", Type: repositories.ATTRIBUTE_NUMBER, Min: &min, Max: &max},
+		{Key: "finSetup", Type: repositories.ATTRIBUTE_ENUM, Options: []string{"single", "twin", "thruster"}},
+		{Key: "shaper", Type: repositories.ATTRIBUTE_TEXT, Required: true},
+	}
+
+	cases := []struct {
+		specs    map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"shaper": "Al Merrick", "volume": 30.5, "finSetup": "twin"}, ""},
+		{map[string]interface{}{"shaper": "Al Merrick", "volume": 130.0}, "volume must be between 10 and 120"},
+		{map[string]interface{}{"shaper": "Al Merrick", "volume": "30"}, "volume must be a number"},
+		{map[string]interface{}{"shaper": "Al Merrick", "finSetup": "quad"}, "finSetup must be one of single, twin, thruster"},
+		{map[string]interface{}{"shaper": "Al Merrick", "flex": 5.0}, "Unknown spec: flex"},
+		{map[string]interface{}{"volume": 30.0}, "shaper is required"},
+	}
+
+	for idx := range cases {
+		assert.Equal(t, cases[idx].expected, validateSpecs(cases[idx].specs, attributes))
+	}
+}
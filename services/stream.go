@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+const (
+	STREAM_HEARTBEAT_INTERVAL = 30 * time.Second
+	LISTEN_RETRY_INTERVAL     = 5 * time.Second
+)
+
+type messageHub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan repositories.Message]bool
+}
+
+func newMessageHub() *messageHub {
+	return &messageHub{
+		subscribers: map[string]map[chan repositories.Message]bool{},
+	}
+}
+
+func (h *messageHub) subscribe(userId string) chan repositories.Message {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan repositories.Message, 16)
+	if _, exists := h.subscribers[userId]; !exists {
+		h.subscribers[userId] = map[chan repositories.Message]bool{}
+	}
+	h.subscribers[userId][ch] = true
+
+	return ch
+}
+
+func (h *messageHub) unsubscribe(userId string, ch chan repositories.Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subscribers[userId], ch)
+	if len(h.subscribers[userId]) <= 0 {
+		delete(h.subscribers, userId)
+	}
+}
+
+func (h *messageHub) hasSubscribers(userIds []string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for idx := range userIds {
+		if len(h.subscribers[userIds[idx]]) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *messageHub) publish(userIds []string, message repositories.Message) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for idx := range userIds {
+		for ch := range h.subscribers[userIds[idx]] {
+			select {
+			case ch <- message:
+			default:
+				log.Println("Dropping message for slow subscriber |", userIds[idx])
+			}
+		}
+	}
+}
+
+func (s *Service) ListenMessageEvents(ctx context.Context) {
+	events := make(chan repositories.MessageEvent)
+
+	go func() {
+		for {
+			err := s.repo.ListenMessageEvents(ctx, events)
+			if ctx.Err() != nil {
+				close(events)
+				return
+			}
+			log.Println("Message listener stopped, retrying |", err)
+			time.Sleep(LISTEN_RETRY_INTERVAL)
+		}
+	}()
+
+	for event := range events {
+		if !s.hub.hasSubscribers(event.Recipients) {
+			continue
+		}
+
+		message, err := s.repo.GetMessage(ctx, event.Id)
+		if err != nil || message == nil {
+			log.Println("Failed to get message for event |", event.Id, err)
+			continue
+		}
+
+		s.hub.publish(event.Recipients, *message)
+	}
+}
+
+func (s *Service) StreamMessages(c *gin.Context) {
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		log.Println("Failed to authorize user |", err)
+		c.JSON(http.StatusUnauthorized, "Failed to authorize user")
+		return
+	}
+
+	ch := s.hub.subscribe(user.Id)
+	defer s.hub.unsubscribe(user.Id, ch)
+
+	heartbeat := time.NewTicker(STREAM_HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case message := <-ch:
+			c.SSEvent("message", message)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("heartbeat", time.Now().Unix())
+			return true
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListenMessageEventsPublishesToRecipients(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockRepo.
+		On("ListenMessageEvents", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			events := args.Get(1).(chan<- repositories.MessageEvent)
+			events <- repositories.MessageEvent{Id: 1, Recipients: []string{"owner", "asker"}}
+			events <- repositories.MessageEvent{Id: 2, Recipients: []string{"nobody"}}
+			cancel()
+		}).
+		Return(context.Canceled)
+	mockRepo.
+		On("GetMessage", mock.Anything, 1).
+		Return(&repositories.Message{Id: 1, UserId: "asker"}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	owner := svc.hub.subscribe("owner")
+	stranger := svc.hub.subscribe("stranger")
+
+	svc.ListenMessageEvents(ctx)
+
+	select {
+	case message := <-owner:
+		assert.Equal(t, 1, message.Id)
+	default:
+		t.Fatal("expected owner to receive message")
+	}
+
+	assert.Len(t, stranger, 0)
+	mockRepo.AssertNumberOfCalls(t, "GetMessage", 1)
+
+	svc.hub.unsubscribe("owner", owner)
+	assert.False(t, svc.hub.hasSubscribers([]string{"owner"}))
+}
@@ -0,0 +1,450 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+const (
+	MAX_VALUE_LABEL_LENGTH = 255
+)
+
+func (s *Service) GetTagTypes(c *gin.Context) {
+	s.getTagTypes(c)
+}
+
+func (s *Service) CreateTagType(c *gin.Context) {
+	s.createTagType(c)
+}
+
+func (s *Service) UpdateTagType(c *gin.Context) {
+	s.updateTagType(c)
+}
+
+func (s *Service) CreateTag(c *gin.Context) {
+	s.createTag(c)
+}
+
+func (s *Service) UpdateTag(c *gin.Context) {
+	s.updateTag(c)
+}
+
+func (s *Service) DeleteTag(c *gin.Context) {
+	s.deleteTag(c)
+}
+
+func (s *Service) getTagTypes(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get tag types |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting tag types")
+		}
+	}()
+
+	tagTypes, err := s.repo.GetTagTypes(c)
+	if err != nil {
+		return fmt.Errorf("failed to get tag types | %w", err)
+	}
+
+	if tagTypes == nil {
+		tagTypes = []repositories.TagType{}
+	}
+
+	c.JSON(http.StatusOK, tagTypes)
+
+	return nil
+}
+
+func (s *Service) createTagType(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to create tag type |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while creating tag type")
+		}
+	}()
+
+	payload := repositories.CreateTagTypePayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Value and label are required")
+		return nil
+	}
+
+	payload.Value, payload.Label = strings.TrimSpace(payload.Value), strings.TrimSpace(payload.Label)
+	msg := validateValueLabel(payload.Value, payload.Label)
+	if msg == "" {
+		msg = validateLabels(payload.Labels)
+	}
+	if msg != "" {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	valid, err := s.validCategoryIds(ctx, payload.CategoryIds)
+	if err != nil {
+		return fmt.Errorf("failed to validate category ids | %w", err)
+	}
+
+	if !valid {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Invalid category id")
+		return nil
+	}
+
+	id, err := s.repo.CreateTagType(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to create tag type | %w", err)
+	}
+
+	if id == nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusConflict, "Tag type already exists")
+		return nil
+	}
+
+	if err := s.repo.SetTagTypeCategories(ctx, *id, payload.CategoryIds); err != nil {
+		return fmt.Errorf("failed to set tag type categories | %w", err)
+	}
+
+	tagType, err := s.repo.GetTagType(ctx, *id)
+	if err != nil {
+		return fmt.Errorf("failed to get tag type | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	c.JSON(http.StatusOK, tagType)
+
+	return nil
+}
+
+func (s *Service) updateTagType(c *gin.Context) (err error) {
+	ctx, _ := s.repo.BeginTxn(c)
+
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update tag type |", err)
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating tag type")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Invalid tag type id")
+		return nil
+	}
+
+	payload := repositories.UpdateTagTypePayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusBadRequest, "Invalid payload")
+		return nil
+	}
+
+	tagType, err := s.repo.GetTagType(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get tag type | %w", err)
+	}
+
+	if tagType == nil {
+		s.repo.RollbackTxn(ctx)
+		c.JSON(http.StatusNotFound, "Tag type not found")
+		return nil
+	}
+
+	if payload.Label != nil || payload.Labels != nil {
+		label := tagType.Label
+		if payload.Label != nil {
+			label = strings.TrimSpace(*payload.Label)
+			payload.Label = &label
+		}
+
+		msg := validateValueLabel(tagType.Value, label)
+		if msg == "" {
+			msg = validateLabels(payload.Labels)
+		}
+		if msg != "" {
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusBadRequest, msg)
+			return nil
+		}
+
+		if err := s.repo.UpdateTagType(ctx, id, payload); err != nil {
+			return fmt.Errorf("failed to update tag type | %w", err)
+		}
+	}
+
+	if payload.CategoryIds != nil {
+		valid, err := s.validCategoryIds(ctx, *payload.CategoryIds)
+		if err != nil {
+			return fmt.Errorf("failed to validate category ids | %w", err)
+		}
+
+		if !valid {
+			s.repo.RollbackTxn(ctx)
+			c.JSON(http.StatusBadRequest, "Invalid category id")
+			return nil
+		}
+
+		if err := s.repo.SetTagTypeCategories(ctx, id, *payload.CategoryIds); err != nil {
+			return fmt.Errorf("failed to set tag type categories | %w", err)
+		}
+	}
+
+	tagType, err = s.repo.GetTagType(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get tag type | %w", err)
+	}
+
+	if err := s.repo.CommitTxn(ctx); err != nil {
+		return fmt.Errorf("failed to commit db txn | %w", err)
+	}
+
+	c.JSON(http.StatusOK, tagType)
+
+	return nil
+}
+
+func (s *Service) createTag(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to create tag |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while creating tag")
+		}
+	}()
+
+	payload := repositories.CreateTagPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, "Type id, value and label are required")
+		return nil
+	}
+
+	payload.Value, payload.Label = strings.TrimSpace(payload.Value), strings.TrimSpace(payload.Label)
+	msg := validateValueLabel(payload.Value, payload.Label)
+	if msg == "" {
+		msg = validateLabels(payload.Labels)
+	}
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	tagType, err := s.repo.GetTagType(c, payload.TypeId)
+	if err != nil {
+		return fmt.Errorf("failed to get tag type | %w", err)
+	}
+
+	if tagType == nil {
+		c.JSON(http.StatusBadRequest, "Invalid tag type id")
+		return nil
+	}
+
+	exists, err := s.repo.TagExists(c, payload.TypeId, payload.Value, nil)
+	if err != nil {
+		return fmt.Errorf("failed to check tag | %w", err)
+	}
+
+	if exists {
+		c.JSON(http.StatusConflict, "Tag already exists")
+		return nil
+	}
+
+	id, err := s.repo.CreateTag(c, payload)
+	if err != nil {
+		return fmt.Errorf("failed to create tag | %w", err)
+	}
+
+	tag, err := s.repo.GetTag(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get tag | %w", err)
+	}
+
+	c.JSON(http.StatusOK, tag)
+
+	return nil
+}
+
+func (s *Service) updateTag(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update tag |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating tag")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid tag id")
+		return nil
+	}
+
+	payload := repositories.UpdateTagPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid payload")
+		return nil
+	}
+
+	tag, err := s.repo.GetTag(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get tag | %w", err)
+	}
+
+	if tag == nil {
+		c.JSON(http.StatusNotFound, "Tag not found")
+		return nil
+	}
+
+	typeId, value, label := tag.TypeId, tag.Value, tag.Label
+	if payload.TypeId != nil {
+		typeId = *payload.TypeId
+	}
+	if payload.Value != nil {
+		value = strings.TrimSpace(*payload.Value)
+		payload.Value = &value
+	}
+	if payload.Label != nil {
+		label = strings.TrimSpace(*payload.Label)
+		payload.Label = &label
+	}
+
+	msg := validateValueLabel(value, label)
+	if msg == "" {
+		msg = validateLabels(payload.Labels)
+	}
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	if typeId != tag.TypeId {
+		tagType, err := s.repo.GetTagType(c, typeId)
+		if err != nil {
+			return fmt.Errorf("failed to get tag type | %w", err)
+		}
+
+		if tagType == nil {
+			c.JSON(http.StatusBadRequest, "Invalid tag type id")
+			return nil
+		}
+	}
+
+	exists, err := s.repo.TagExists(c, typeId, value, &id)
+	if err != nil {
+		return fmt.Errorf("failed to check tag | %w", err)
+	}
+
+	if exists {
+		c.JSON(http.StatusConflict, "Tag already exists")
+		return nil
+	}
+
+	if err := s.repo.UpdateTag(c, id, payload); err != nil {
+		return fmt.Errorf("failed to update tag | %w", err)
+	}
+
+	tag, err = s.repo.GetTag(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get tag | %w", err)
+	}
+
+	c.JSON(http.StatusOK, tag)
+
+	return nil
+}
+
+func (s *Service) deleteTag(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to delete tag |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while deleting tag")
+		}
+	}()
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Invalid tag id")
+		return nil
+	}
+
+	tag, err := s.repo.GetTag(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get tag | %w", err)
+	}
+
+	if tag == nil {
+		c.JSON(http.StatusNotFound, "Tag not found")
+		return nil
+	}
+
+	count, err := s.repo.GetTagPostCount(c, id)
+	if err != nil {
+		return fmt.Errorf("failed to get tag post count | %w", err)
+	}
+
+	if count > 0 {
+		c.JSON(http.StatusConflict, fmt.Sprintf("Tag is used by %d posts", count))
+		return nil
+	}
+
+	if err := s.repo.DeleteTag(c, id); err != nil {
+		return fmt.Errorf("failed to delete tag | %w", err)
+	}
+
+	c.Status(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Service) validCategoryIds(ctx context.Context, categoryIds []int) (bool, error) {
+	if len(categoryIds) <= 0 {
+		return true, nil
+	}
+
+	categories, err := s.repo.GetCategories(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	exists := map[int]bool{}
+	for idx := range categories {
+		if categories[idx].Id != nil {
+			exists[*categories[idx].Id] = true
+		}
+	}
+
+	for idx := range categoryIds {
+		if !exists[categoryIds[idx]] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func validateValueLabel(value string, label string) string {
+	if value == "" || utf8.RuneCountInString(value) > MAX_VALUE_LABEL_LENGTH {
+		return fmt.Sprintf("Value must be between 1 and %d characters", MAX_VALUE_LABEL_LENGTH)
+	}
+
+	if label == "" || utf8.RuneCountInString(label) > MAX_VALUE_LABEL_LENGTH {
+		return fmt.Sprintf("Label must be between 1 and %d characters", MAX_VALUE_LABEL_LENGTH)
+	}
+
+	return ""
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteTagInUse(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+	mockRepo.
+		On("GetTag", mock.Anything, 3).
+		Return(&repositories.Tag{Id: 3, TypeId: 1, Value: "beginner"}, nil)
+	mockRepo.
+		On("GetTagPostCount", mock.Anything, 3).
+		Return(2, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.DELETE("/tags/:id", svc.DeleteTag)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tags/3", nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockRepo.AssertNotCalled(t, "DeleteTag", mock.Anything, mock.Anything)
+}
@@ -4,9 +4,22 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/katakeda/boardhop-api-service-go/utils"
+)
+
+const (
+	MAX_NAME_LENGTH  = 50
+	MAX_PHONE_LENGTH = 50
+	MAX_BIO_LENGTH   = 1000
+	MAX_AVATAR_SIZE  = 5 << 20
 )
 
 func (s *Service) UserSignup(c *gin.Context) {
@@ -14,16 +27,9 @@ func (s *Service) UserSignup(c *gin.Context) {
 }
 
 func (s *Service) UserLogin(c *gin.Context) {
-	payload := repositories.UserLoginPayload{}
-	if err := c.BindJSON(&payload); err != nil {
-		log.Println("Failed to parse payload", err)
-		c.JSON(http.StatusInternalServerError, "Something went wrong during login")
-		return
-	}
-
-	user, err := s.repo.GetUserByGoogleAuthId(c, payload.GoogleAuthId)
+	user, err := s.getUser(c)
 	if err != nil {
-		log.Println("Failed to get user", err)
+		log.Println("Failed to get user |", err)
 		c.JSON(http.StatusNotFound, "Failed to get user")
 		return
 	}
@@ -59,6 +65,10 @@ func (s *Service) getUser(c *gin.Context) (user *repositories.User, err error) {
 		return nil, fmt.Errorf("failed to fetch user | %w", err)
 	}
 
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
 	return user, nil
 }
 
@@ -71,16 +81,307 @@ func (s *Service) userSignup(c *gin.Context) (err error) {
 	}()
 
 	payload := repositories.UserSignupPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, "First name and last name are required")
+		return nil
+	}
+
+	googleAuthId, _ := c.Get("googleAuthId")
+	email, _ := c.Get("googleAuthEmail")
+
+	payload.GoogleAuthId, _ = googleAuthId.(string)
+	payload.Email, _ = email.(string)
+	payload.EmailVerified = c.GetBool("googleAuthEmailVerified")
+	if payload.GoogleAuthId == "" || payload.Email == "" {
+		c.JSON(http.StatusBadRequest, "ID token must include an email")
+		return nil
+	}
+
+	if msg := validateUserPayload(&repositories.UpdateUserPayload{
+		FirstName: &payload.FirstName,
+		LastName:  &payload.LastName,
+	}); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	user, err := s.repo.GetUserByGoogleAuthId(c, payload.GoogleAuthId)
+	if err != nil {
+		return fmt.Errorf("failed to get user | %w", err)
+	}
+
+	if user != nil {
+		c.JSON(http.StatusOK, user)
+		return nil
+	}
+
+	if _, err := s.repo.UserSignup(c, payload); err != nil {
+		return fmt.Errorf("failed to insert user | %w", err)
+	}
+
+	user, err = s.repo.GetUserByGoogleAuthId(c, payload.GoogleAuthId)
+	if err != nil {
+		return fmt.Errorf("failed to get user | %w", err)
+	}
+
+	if user == nil && !payload.EmailVerified {
+		c.JSON(http.StatusConflict, "Email must be verified to link an existing account")
+		return nil
+	}
+
+	if user == nil {
+		c.JSON(http.StatusConflict, "Email is already registered to another account")
+		return nil
+	}
+
+	c.JSON(http.StatusOK, user)
+
+	return nil
+}
+
+func (s *Service) GetPublicUser(c *gin.Context) {
+	s.getPublicUser(c)
+}
+
+func (s *Service) UpdateUser(c *gin.Context) {
+	s.updateUser(c)
+}
+
+func (s *Service) UploadAvatar(c *gin.Context) {
+	s.uploadAvatar(c)
+}
+
+func (s *Service) getPublicUser(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get public user |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting user")
+		}
+	}()
+
+	user, err := s.repo.GetPublicUser(c, c.Param("id"))
+	if err != nil {
+		return fmt.Errorf("failed to get public user | %w", err)
+	}
+
+	if user == nil {
+		c.JSON(http.StatusNotFound, "User not found")
+		return nil
+	}
+
+	params := url.Values{"uid": {user.Id}, "p": {c.Query("p")}, "l": {c.Query("l")}}
+	if user.ActiveListings, err = s.repo.GetPosts(localize(c), params); err != nil {
+		return fmt.Errorf("failed to get active listings | %w", err)
+	}
+
+	if user.ActiveListings == nil {
+		user.ActiveListings = []repositories.Post{}
+	}
+
+	c.JSON(http.StatusOK, user)
+
+	return nil
+}
+
+func (s *Service) updateUser(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update user |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating user")
+		}
+	}()
+
+	payload := repositories.UpdateUserPayload{}
 	if err := c.BindJSON(&payload); err != nil {
 		return fmt.Errorf("failed to parse payload | %w", err)
 	}
 
-	user, err := s.repo.UserSignup(c, payload)
+	if msg := validateUserPayload(&payload); msg != "" {
+		c.JSON(http.StatusBadRequest, msg)
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	if err := s.repo.UpdateUser(c, user.Id, payload); err != nil {
+		return fmt.Errorf("failed to update user | %w", err)
+	}
+
+	user, err = s.repo.GetUserById(c, user.Id)
 	if err != nil {
-		return fmt.Errorf("failed to insert user | %w", err)
+		return fmt.Errorf("failed to get user | %w", err)
+	}
+
+	c.JSON(http.StatusOK, user)
+
+	return nil
+}
+
+func (s *Service) uploadAvatar(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to upload avatar |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while uploading avatar")
+		}
+	}()
+
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, "Avatar image is required")
+		return nil
+	}
+
+	if file.Size > MAX_AVATAR_SIZE {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("Avatar must be %dMB or less", MAX_AVATAR_SIZE>>20))
+		return nil
+	}
+
+	if !strings.HasPrefix(file.Header.Get("Content-Type"), "image/") {
+		c.JSON(http.StatusBadRequest, "Avatar must be an image")
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	bucket, err := utils.GetDefaultBucket(c)
+	if err != nil {
+		return fmt.Errorf("failed to get bucket | %w", err)
+	}
+
+	object := fmt.Sprintf("avatars/%s/%d%s", user.Id, time.Now().UnixNano(), filepath.Ext(file.Filename))
+	if err := utils.UploadFile(c, bucket, object, file); err != nil {
+		return fmt.Errorf("failed to upload avatar | %w", err)
+	}
+
+	avatarUrl := utils.GetObjectUrl(object)
+	if err := s.repo.UpdateUser(c, user.Id, repositories.UpdateUserPayload{AvatarUrl: &avatarUrl}); err != nil {
+		if err := utils.DeleteFile(c, bucket, object); err != nil {
+			log.Println("Failed to delete avatar |", err)
+		}
+		return fmt.Errorf("failed to update user | %w", err)
+	}
+
+	if user.AvatarUrl != nil {
+		if prev, ok := utils.GetObjectName(*user.AvatarUrl); ok && strings.HasPrefix(prev, "avatars/"+user.Id+"/") {
+			if err := utils.DeleteFile(c, bucket, prev); err != nil {
+				log.Println("Failed to delete previous avatar |", err)
+			}
+		}
+	}
+
+	user, err = s.repo.GetUserById(c, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get user | %w", err)
 	}
 
 	c.JSON(http.StatusOK, user)
 
 	return nil
 }
+
+func validateUserPayload(payload *repositories.UpdateUserPayload) string {
+	for _, field := range []struct {
+		name  string
+		value *string
+		max   int
+	}{
+		{"First name", payload.FirstName, MAX_NAME_LENGTH},
+		{"Last name", payload.LastName, MAX_NAME_LENGTH},
+		{"Phone", payload.Phone, MAX_PHONE_LENGTH},
+		{"Bio", payload.Bio, MAX_BIO_LENGTH},
+	} {
+		if field.value == nil {
+			continue
+		}
+		*field.value = strings.TrimSpace(*field.value)
+		if utf8.RuneCountInString(*field.value) > field.max {
+			return fmt.Sprintf("%s must be %d characters or less", field.name, field.max)
+		}
+	}
+
+	if payload.FirstName != nil && *payload.FirstName == "" {
+		return "First name cannot be empty"
+	}
+
+	if payload.LastName != nil && *payload.LastName == "" {
+		return "Last name cannot be empty"
+	}
+
+	return ""
+}
+
+func (s *Service) GetNotificationPreference(c *gin.Context) {
+	s.getNotificationPreference(c)
+}
+
+func (s *Service) UpdateNotificationPreference(c *gin.Context) {
+	s.updateNotificationPreference(c)
+}
+
+func (s *Service) getNotificationPreference(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to get notification preference |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while getting notification preference")
+		}
+	}()
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	preference, err := s.repo.GetNotificationPreference(c, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preference | %w", err)
+	}
+
+	c.JSON(http.StatusOK, preference)
+
+	return nil
+}
+
+func (s *Service) updateNotificationPreference(c *gin.Context) (err error) {
+	defer func() {
+		if err != nil {
+			log.Println("Failed to update notification preference |", err)
+			c.JSON(http.StatusInternalServerError, "Something went wrong while updating notification preference")
+		}
+	}()
+
+	payload := repositories.UpdateNotificationPreferencePayload{}
+	if err := c.BindJSON(&payload); err != nil {
+		return fmt.Errorf("failed to parse payload | %w", err)
+	}
+
+	if payload.Locale != nil && !SUPPORTED_LOCALES[*payload.Locale] {
+		c.JSON(http.StatusBadRequest, "Unsupported locale")
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil || user == nil {
+		return fmt.Errorf("failed to authorize user | %w", err)
+	}
+
+	payload.UserId = user.Id
+
+	if err := s.repo.UpdateNotificationPreference(c, payload); err != nil {
+		return fmt.Errorf("failed to update notification preference | %w", err)
+	}
+
+	preference, err := s.repo.GetNotificationPreference(c, user.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preference | %w", err)
+	}
+
+	c.JSON(http.StatusOK, preference)
+
+	return nil
+}
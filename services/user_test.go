@@ -0,0 +1,203 @@
+package services
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/mocks"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserSignupIsIdempotent(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "existing-uid").
+		Return(&repositories.User{Id: "user-1", Email: "taro@example.com"}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.POST("/user/signup", func(c *gin.Context) {
+		c.Set("googleAuthId", "existing-uid")
+		c.Set("googleAuthEmail", "taro@example.com")
+	}, svc.UserSignup)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/user/signup", strings.NewReader(`{"firstName":"Taro","lastName":"Yamada"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"user-1"`)
+	mockRepo.AssertNotCalled(t, "UserSignup", mock.Anything, mock.Anything)
+}
+
+func TestGetPublicUserIncludesListings(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetPublicUser", mock.Anything, "owner").
+		Return(&repositories.PublicUser{Id: "owner", ActiveListingCount: 3}, nil)
+	mockRepo.
+		On("GetPosts", mock.Anything, mock.MatchedBy(func(params url.Values) bool {
+			return params.Get("uid") == "owner" && params.Get("p") == "1" && params.Get("l") == "2"
+		})).
+		Return([]repositories.Post{{Id: "post-3", UserId: "owner"}}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.GET("/users/:id", svc.GetPublicUser)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/owner?p=1&l=2", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"activeListingCount":3`)
+	assert.Contains(t, w.Body.String(), `"activeListings":[{"id":"post-3"`)
+}
+
+func TestUserSignupRequiresVerifiedEmailToLink(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "new-uid").
+		Return(nil, nil)
+	mockRepo.
+		On("UserSignup", mock.Anything, mock.MatchedBy(func(payload repositories.UserSignupPayload) bool {
+			return payload.Email == "taro@example.com" && !payload.EmailVerified
+		})).
+		Return(nil, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.POST("/user/signup", func(c *gin.Context) {
+		c.Set("googleAuthId", "new-uid")
+		c.Set("googleAuthEmail", "taro@example.com")
+		c.Set("googleAuthEmailVerified", false)
+	}, svc.UserSignup)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/user/signup", strings.NewReader(`{"firstName":"Taro","lastName":"Yamada"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "verified")
+}
+
+func TestUpdateUserValidation(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.PATCH("/user", func(c *gin.Context) { c.Set("googleAuthId", "owner-uid") }, svc.UpdateUser)
+
+	cases := []struct {
+		body     string
+		expected string
+	}{
+		{`{"firstName":"` + strings.Repeat("a", MAX_NAME_LENGTH+1) + `"}`, "First name must be 50 characters or less"},
+		{`{"bio":"` + strings.Repeat("あ", MAX_BIO_LENGTH+1) + `"}`, "Bio must be 1000 characters or less"},
+		{`{"firstName":"   "}`, "First name cannot be empty"},
+		{`{"lastName":""}`, "Last name cannot be empty"},
+	}
+
+	for idx := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(cases[idx].body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, cases[idx].body)
+		assert.Contains(t, w.Body.String(), cases[idx].expected)
+	}
+
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateUser(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+
+	mockRepo.
+		On("GetUserByGoogleAuthId", mock.Anything, "owner-uid").
+		Return(&repositories.User{Id: "owner"}, nil)
+	mockRepo.
+		On("UpdateUser", mock.Anything, "owner", mock.MatchedBy(func(payload repositories.UpdateUserPayload) bool {
+			return *payload.FirstName == "Taro" && *payload.Bio == "Surfing in Shonan" && payload.LastName == nil
+		})).
+		Return(nil)
+	mockRepo.
+		On("GetUserById", mock.Anything, "owner").
+		Return(&repositories.User{Id: "owner", FirstName: "Taro"}, nil)
+
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.PATCH("/user", func(c *gin.Context) { c.Set("googleAuthId", "owner-uid") }, svc.UpdateUser)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/user", strings.NewReader(`{"firstName":" Taro ","bio":"Surfing in Shonan"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"firstName":"Taro"`)
+	mockRepo.AssertNumberOfCalls(t, "UpdateUser", 1)
+}
+
+func TestUploadAvatarRejectsInvalidFiles(t *testing.T) {
+	mockRepo := new(mocks.IRepository)
+	svc, _ := NewService(mockRepo)
+
+	router := gin.New()
+	router.POST("/user/avatar", func(c *gin.Context) { c.Set("googleAuthId", "owner-uid") }, svc.UploadAvatar)
+
+	cases := []struct {
+		contentType string
+		size        int
+		expected    string
+	}{
+		{"image/png", MAX_AVATAR_SIZE + 1, "Avatar must be 5MB or less"},
+		{"application/pdf", 1024, "Avatar must be an image"},
+	}
+
+	for idx := range cases {
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="avatar"; filename="avatar"`)
+		header.Set("Content-Type", cases[idx].contentType)
+		part, _ := form.CreatePart(header)
+		part.Write(bytes.Repeat([]byte{0}, cases[idx].size))
+		form.Close()
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/user/avatar", body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, cases[idx].contentType)
+		assert.Contains(t, w.Body.String(), cases[idx].expected)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/user/avatar", strings.NewReader(""))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=none")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Avatar image is required")
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything)
+}
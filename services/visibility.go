@@ -0,0 +1,90 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/katakeda/boardhop-api-service-go/repositories"
+)
+
+func (s *Service) getViewer(c *gin.Context) *repositories.User {
+	if _, ok := c.Get("googleAuthId"); !ok {
+		return nil
+	}
+
+	user, err := s.getUser(c)
+	if err != nil {
+		log.Println("Failed to get viewer |", err)
+		return nil
+	}
+
+	return user
+}
+
+func (s *Service) canViewContact(c *gin.Context, post *repositories.Post, viewer *repositories.User) (bool, error) {
+	if viewer == nil {
+		return false, nil
+	}
+
+	if post.UserId == viewer.Id {
+		return true, nil
+	}
+
+	orders, err := s.repo.GetOrders(c, repositories.GetOrdersFilter{UserId: &viewer.Id, PostId: &post.Id})
+	if err != nil {
+		return false, fmt.Errorf("failed to get orders | %w", err)
+	}
+
+	for idx := range orders {
+		if ACCEPTED_ORDER_STATUSES[orders[idx].Status] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *Service) setPostVisibility(c *gin.Context, post *repositories.Post, showContact bool) error {
+	if !showContact {
+		post.Email, post.Phone = nil, nil
+		if post.LastName != nil {
+			post.LastName = initial(*post.LastName)
+		}
+		return nil
+	}
+
+	owner, err := s.repo.GetUserById(c, post.UserId)
+	if err != nil {
+		return fmt.Errorf("failed to get post owner | %w", err)
+	}
+
+	if owner == nil {
+		return fmt.Errorf("post owner not found: %s", post.UserId)
+	}
+
+	post.Email, post.Phone = &owner.Email, owner.Phone
+	post.FirstName, post.LastName = &owner.FirstName, &owner.LastName
+
+	return nil
+}
+
+func initial(name string) *string {
+	r, _ := utf8.DecodeRuneInString(name)
+	if r == utf8.RuneError {
+		return nil
+	}
+
+	abbr := string(r) + "."
+
+	return &abbr
+}
+
+func canViewModerated(post *repositories.Post, viewer *repositories.User) bool {
+	if viewer == nil {
+		return false
+	}
+
+	return post.UserId == viewer.Id || viewer.Role == repositories.ROLE_ADMIN
+}
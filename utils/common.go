@@ -1,5 +1,9 @@
 package utils
 
+import "regexp"
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 func StrArrayToMap(input []string) map[string]bool {
 	output := make(map[string]bool, len(input))
 	for idx := range input {
@@ -8,3 +12,7 @@ func StrArrayToMap(input []string) map[string]bool {
 
 	return output
 }
+
+func IsUUID(input string) bool {
+	return uuidRegexp.MatchString(input)
+}
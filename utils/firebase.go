@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/url"
 	"os"
+	"strings"
 
 	"cloud.google.com/go/storage"
 
@@ -22,14 +24,46 @@ func GetDefaultBucket(ctx context.Context) (bucket *storage.BucketHandle, err er
 	app, err := firebase.NewApp(ctx, &firebase.Config{
 		StorageBucket: os.Getenv("FIREBASE_DEFAULT_BUCKET_NAME"),
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	client, err := app.Storage(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	bucket, err = client.DefaultBucket()
 
 	return
 }
 
+func GetObjectUrl(object string) string {
+	return fmt.Sprintf(
+		"https://firebasestorage.googleapis.com/v0/b/%s/o/%s?alt=media",
+		os.Getenv("FIREBASE_DEFAULT_BUCKET_NAME"),
+		url.PathEscape(object),
+	)
+}
+
+func GetObjectName(objectUrl string) (string, bool) {
+	prefix := fmt.Sprintf(
+		"https://firebasestorage.googleapis.com/v0/b/%s/o/",
+		os.Getenv("FIREBASE_DEFAULT_BUCKET_NAME"),
+	)
+	if !strings.HasPrefix(objectUrl, prefix) {
+		return "", false
+	}
+
+	escaped := strings.SplitN(strings.TrimPrefix(objectUrl, prefix), "?", 2)[0]
+	object, err := url.PathUnescape(escaped)
+	if err != nil || object == "" {
+		return "", false
+	}
+
+	return object, true
+}
+
 func UploadFile(ctx context.Context, bucket *storage.BucketHandle, object string, file *multipart.FileHeader) error {
 	f, err := file.Open()
 	if err != nil {
@@ -40,6 +74,7 @@ func UploadFile(ctx context.Context, bucket *storage.BucketHandle, object string
 	objHandle := bucket.Object(object)
 
 	wc := objHandle.NewWriter(ctx)
+	wc.ContentType = file.Header.Get("Content-Type")
 	defer wc.Close()
 
 	if _, err = io.Copy(wc, f); err != nil {
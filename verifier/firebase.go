@@ -0,0 +1,45 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+
+	firebase "firebase.google.com/go"
+	"firebase.google.com/go/auth"
+)
+
+type FirebaseVerifier struct {
+	client *auth.Client
+}
+
+func NewFirebaseVerifier(ctx context.Context) (*FirebaseVerifier, error) {
+	app, err := firebase.NewApp(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize firebase app | %w", err)
+	}
+
+	client, err := app.Auth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth client | %w", err)
+	}
+
+	return &FirebaseVerifier{
+		client: client,
+	}, nil
+}
+
+func (v *FirebaseVerifier) Verify(ctx context.Context, idToken string) (*Token, error) {
+	token, err := v.client.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify ID token | %w", err)
+	}
+
+	email, _ := token.Claims["email"].(string)
+	emailVerified, _ := token.Claims["email_verified"].(bool)
+
+	return &Token{
+		UID:           token.UID,
+		Email:         email,
+		EmailVerified: emailVerified,
+	}, nil
+}
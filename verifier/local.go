@@ -0,0 +1,182 @@
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ALG_HS256 = "HS256"
+	ALG_RS256 = "RS256"
+)
+
+type LocalVerifier struct {
+	alg    string
+	secret []byte
+	key    *rsa.PublicKey
+}
+
+type localHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type localClaims struct {
+	Sub           string `json:"sub"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty"`
+	Iat           int64  `json:"iat"`
+	Exp           int64  `json:"exp"`
+}
+
+func NewHMACVerifier(secret []byte) *LocalVerifier {
+	return &LocalVerifier{
+		alg:    ALG_HS256,
+		secret: secret,
+	}
+}
+
+func NewRSAVerifier(key interface{}) (*LocalVerifier, error) {
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key must be RSA")
+	}
+
+	return &LocalVerifier{
+		alg: ALG_RS256,
+		key: rsaKey,
+	}, nil
+}
+
+func (v *LocalVerifier) Verify(ctx context.Context, idToken string) (*Token, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed ID token")
+	}
+
+	var header localHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("failed to decode header | %w", err)
+	}
+
+	if header.Alg != v.alg {
+		return nil, fmt.Errorf("unexpected signing algorithm: %s", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature | %w", err)
+	}
+
+	if err := v.verifySignature(parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims localClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode claims | %w", err)
+	}
+
+	if claims.Sub == "" {
+		return nil, fmt.Errorf("ID token has no subject")
+	}
+
+	if claims.Exp == 0 || time.Now().Unix() >= claims.Exp {
+		return nil, fmt.Errorf("ID token has expired")
+	}
+
+	return &Token{
+		UID:           claims.Sub,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+	}, nil
+}
+
+func (v *LocalVerifier) verifySignature(signed string, signature []byte) error {
+	switch v.alg {
+	case ALG_HS256:
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("invalid ID token signature")
+		}
+	case ALG_RS256:
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(v.key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("invalid ID token signature | %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported signing algorithm: %s", v.alg)
+	}
+
+	return nil
+}
+
+func SignHMAC(secret []byte, token Token, ttl time.Duration) (string, error) {
+	return sign(ALG_HS256, token, ttl, func(signed string) ([]byte, error) {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signed))
+		return mac.Sum(nil), nil
+	})
+}
+
+func SignRSA(key *rsa.PrivateKey, token Token, ttl time.Duration) (string, error) {
+	return sign(ALG_RS256, token, ttl, func(signed string) ([]byte, error) {
+		digest := sha256.Sum256([]byte(signed))
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	})
+}
+
+func sign(alg string, token Token, ttl time.Duration, signFn func(signed string) ([]byte, error)) (string, error) {
+	now := time.Now()
+
+	header, err := encodeSegment(localHeader{Alg: alg, Typ: "JWT"})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode header | %w", err)
+	}
+
+	claims, err := encodeSegment(localClaims{
+		Sub:           token.UID,
+		Email:         token.Email,
+		EmailVerified: token.EmailVerified,
+		Iat:           now.Unix(),
+		Exp:           now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode claims | %w", err)
+	}
+
+	signature, err := signFn(header + "." + claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token | %w", err)
+	}
+
+	return header + "." + claims + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func encodeSegment(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
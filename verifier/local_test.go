@@ -0,0 +1,49 @@
+package verifier
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMACVerifier(t *testing.T) {
+	v := NewHMACVerifier([]byte("secret"))
+
+	idToken, err := SignHMAC([]byte("secret"), Token{UID: "uid-1", Email: "taro@example.com", EmailVerified: true}, time.Hour)
+	assert.NoError(t, err)
+
+	token, err := v.Verify(context.Background(), idToken)
+	assert.NoError(t, err)
+	assert.Equal(t, &Token{UID: "uid-1", Email: "taro@example.com", EmailVerified: true}, token)
+
+	forged, _ := SignHMAC([]byte("other"), Token{UID: "uid-1"}, time.Hour)
+	_, err = v.Verify(context.Background(), forged)
+	assert.Error(t, err)
+
+	expired, _ := SignHMAC([]byte("secret"), Token{UID: "uid-1"}, -time.Minute)
+	_, err = v.Verify(context.Background(), expired)
+	assert.Error(t, err)
+}
+
+func TestRSAVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	v, err := NewRSAVerifier(&key.PublicKey)
+	assert.NoError(t, err)
+
+	idToken, err := SignRSA(key, Token{UID: "uid-2"}, time.Hour)
+	assert.NoError(t, err)
+
+	token, err := v.Verify(context.Background(), idToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "uid-2", token.UID)
+
+	hmacToken, _ := SignHMAC([]byte("secret"), Token{UID: "uid-2"}, time.Hour)
+	_, err = v.Verify(context.Background(), hmacToken)
+	assert.Error(t, err)
+}
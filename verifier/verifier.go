@@ -0,0 +1,57 @@
+package verifier
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+type Token struct {
+	UID           string
+	Email         string
+	EmailVerified bool
+}
+
+type Verifier interface {
+	Verify(ctx context.Context, idToken string) (*Token, error)
+}
+
+func NewVerifier(ctx context.Context) (Verifier, error) {
+	switch os.Getenv("TOKEN_VERIFIER") {
+	case "", "firebase":
+		return NewFirebaseVerifier(ctx)
+	case "local":
+		return newLocalVerifierFromEnv()
+	default:
+		return nil, fmt.Errorf("unknown token verifier: %s", os.Getenv("TOKEN_VERIFIER"))
+	}
+}
+
+func newLocalVerifierFromEnv() (Verifier, error) {
+	if path := os.Getenv("LOCAL_TOKEN_PUBLIC_KEY_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key | %w", err)
+		}
+
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("failed to decode public key: %s", path)
+		}
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key | %w", err)
+		}
+
+		return NewRSAVerifier(key)
+	}
+
+	if secret := os.Getenv("LOCAL_TOKEN_SECRET"); secret != "" {
+		return NewHMACVerifier([]byte(secret)), nil
+	}
+
+	return nil, fmt.Errorf("LOCAL_TOKEN_SECRET or LOCAL_TOKEN_PUBLIC_KEY_FILE is required for local token verifier")
+}